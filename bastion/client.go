@@ -6,15 +6,24 @@ import (
 	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// Default number of objects requested by page when listing all objects of an endpoint.
+const defaultListPageSize = 100
+
 // Information to connect on Wallix bastion.
 type Client struct {
 	bastionPort       int
@@ -23,29 +32,231 @@ type Client struct {
 	bastionToken      string
 	bastionUser       string
 	bastionPwd        string
+	strictJSON        bool
+	listPageSize      int
+	ignoreFields      []string
+	httpClient        *http.Client
+	// serializes the changes of approvers of authorizations made by read-modify-write
+	approversMutex sync.Mutex
+	// end of the rate limit window when the api has advertised no remaining request
+	rateLimitMutex sync.Mutex
+	rateLimitReset time.Time
 }
 
 var defaultHTTPClient *http.Client //nolint:gochecknoglobals
 
 func init() { //nolint:gochecknoinits
+	defaultHTTPClient = newHTTPClient(nil, tls.VersionTLS12)
+}
+
+// newHTTPClient returns a http client using proxyURL as proxy
+// or the proxy from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables when proxyURL is nil,
+// and refusing TLS versions older than minTLSVersion.
+func newHTTPClient(proxyURL *url.URL, minTLSVersion uint16) *http.Client {
 	transport := cleanhttp.DefaultPooledTransport()
-	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint: gosec
-	defaultHTTPClient = &http.Client{Transport: transport}
+	transport.TLSClientConfig = &tls.Config{ //nolint: gosec
+		InsecureSkipVerify: true,
+		MinVersion:         minTLSVersion,
+	}
+	if proxyURL != nil {
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return &http.Client{Transport: transport}
 }
 
+// newRequest sends a request to the api and returns the body and the status code of the response.
 func (c *Client) newRequest(ctx context.Context, uri string, method string, jsonBody interface{}) (string, int, error) {
+	resp, err := c.sendRequest(ctx, uri, method, jsonBody)
+	if err != nil {
+		return "", http.StatusInternalServerError, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", http.StatusInternalServerError, fmt.Errorf("reading http response: %w", err)
+	}
+
+	return string(respBody), resp.StatusCode, nil
+}
+
+// putIfChanged sends jsonBody with a PUT on uri only when it's different from priorJSONBody,
+// the json prepared with the values of the last read (see priorResourceData),
+// so a diff which is a no-op for the api doesn't trigger a write on the Bastion.
+// A skipped PUT returns an empty body with NoContent.
+// A PUT refused with 412 (Precondition Failed), because the object has been modified at the same time
+// by another client, is sent once again after a merge with the object read again (see mergeConcurrentUpdate).
+// Other errors like a 409 (Conflict) of a name already used are returned without a retry.
+func (c *Client) putIfChanged(
+	ctx context.Context, uri string, jsonBody, priorJSONBody interface{},
+) (
+	string, int, error,
+) {
+	if priorJSONBody != nil && sameJSON(jsonBody, priorJSONBody) {
+		tflog.Debug(ctx, "api request skipped, the json to put is the same as with the values of the last read",
+			map[string]interface{}{
+				"uri": uri,
+			})
+
+		return "", http.StatusNoContent, nil
+	}
+	body, code, err := c.newRequest(ctx, uri, http.MethodPut, jsonBody)
+	if err != nil || code != http.StatusPreconditionFailed || priorJSONBody == nil {
+		return body, code, err
+	}
+	tflog.Debug(ctx, "api request refused by a concurrent modification, retrying once after a merge",
+		map[string]interface{}{
+			"uri": uri,
+		})
+	merged, err := c.mergeConcurrentUpdate(ctx, uri, jsonBody, priorJSONBody)
+	if err != nil {
+		return "", http.StatusInternalServerError, err
+	}
+	body, code, err = c.newRequest(ctx, uri, http.MethodPut, merged)
+	if err == nil && code == http.StatusPreconditionFailed {
+		return body, code, fmt.Errorf("object modified concurrently on the Bastion, "+
+			"api doesn't accept the update after a retry: %d with body:\n%s\n"+
+			"run terraform again to update it from its current state", code, body)
+	}
+
+	return body, code, err
+}
+
+// mergeConcurrentUpdate reads again the object of uri modified by another client
+// and returns jsonBody with the values of this object for the fields not changed by the update
+// (the same in jsonBody and priorJSONBody), so the changes of the other client are kept.
+// A field changed by the update and by the other client can't be merged and returns an error.
+func (c *Client) mergeConcurrentUpdate(
+	ctx context.Context, uri string, jsonBody, priorJSONBody interface{},
+) (
+	map[string]interface{}, error,
+) {
+	objectURI, _, _ := strings.Cut(uri, "?")
+	body, code, err := c.newRequest(ctx, objectURI, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	if code != http.StatusOK {
+		return nil, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+	}
+	var current map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &current); err != nil {
+		return nil, fmt.Errorf("unmarshaling json: %w", err)
+	}
+	merged, err := jsonObject(jsonBody)
+	if err != nil {
+		return nil, err
+	}
+	prior, err := jsonObject(priorJSONBody)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range merged {
+		currentValue, ok := current[k]
+		// not read back (like a password) or not changed by the other client
+		if !ok || sameJSON(currentValue, prior[k]) {
+			continue
+		}
+		switch {
+		case sameJSON(v, prior[k]):
+			merged[k] = currentValue
+		case !sameJSON(v, currentValue):
+			return nil, fmt.Errorf("object modified concurrently on the Bastion, "+
+				"%s changed by another client and by the update: "+
+				"run terraform again to update it from its current state", k)
+		}
+	}
+
+	return merged, nil
+}
+
+// jsonObject returns the fields of the json object encoding v.
+func jsonObject(v interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling json: %w", err)
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return nil, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return object, nil
+}
+
+// sameJSON returns true when a and b have the same json encoding,
+// decoded and encoded again to normalize the order of the keys and the spaces of raw values.
+func sameJSON(a, b interface{}) bool {
+	var normalized [2][]byte
+	for i, v := range []interface{}{a, b} {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return false
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			return false
+		}
+		normalized[i], _ = json.Marshal(decoded)
+	}
+
+	return bytes.Equal(normalized[0], normalized[1])
+}
+
+// newRequestJSON is like newRequest but when the api returns OK,
+// the response body is decoded in v while reading it instead of reading all the body in memory first.
+// The body is only returned with the other status codes.
+func (c *Client) newRequestJSON(
+	ctx context.Context, uri string, method string, jsonBody interface{}, v interface{},
+) (
+	string, int, error,
+) {
+	if c.strictJSON {
+		// need the full body to log unknown fields
+		body, code, err := c.newRequest(ctx, uri, method, jsonBody)
+		if err != nil || code != http.StatusOK {
+			return body, code, err
+		}
+		if err := c.unmarshalBody(ctx, body, v); err != nil {
+			return "", code, err
+		}
+
+		return "", code, nil
+	}
+	resp, err := c.sendRequest(ctx, uri, method, jsonBody)
+	if err != nil {
+		return "", http.StatusInternalServerError, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return "", http.StatusInternalServerError, fmt.Errorf("reading http response: %w", err)
+		}
+
+		return string(respBody), resp.StatusCode, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(asJSONList(v)); err != nil && !errors.Is(err, io.EOF) {
+		return "", resp.StatusCode, fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return "", resp.StatusCode, nil
+}
+
+func (c *Client) sendRequest(
+	ctx context.Context, uri string, method string, jsonBody interface{},
+) (
+	*http.Response, error,
+) {
 	body := new(bytes.Buffer)
 	err := json.NewEncoder(body).Encode(jsonBody)
 	if err != nil {
-		return "", http.StatusInternalServerError, fmt.Errorf("decoding json: %w", err)
+		return nil, fmt.Errorf("decoding json: %w", err)
 	}
-	url := "https://" + c.bastionIP + ":" + strconv.Itoa(c.bastionPort) + "/api/" + c.bastionAPIVersion
-	if strings.HasPrefix(uri, "/") {
-		url += uri
-	} else {
-		url += "/" + uri
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL(uri), body)
+	if err != nil {
+		return nil, fmt.Errorf("preparing http request: %w", err)
 	}
-	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	req.Header.Add("Content-Type", "application/json; charset=utf-8")
 	req.Header.Add("User-Agent", "terraform-provider-wallix-bastion")
 	if c.bastionToken != "" {
@@ -56,18 +267,213 @@ func (c *Client) newRequest(ctx context.Context, uri string, method string, json
 		encodedcreds := base64.StdEncoding.EncodeToString([]byte(rawcreds))
 		req.Header.Add("Authorization", "Basic "+encodedcreds)
 	}
-	if err != nil {
-		return "", http.StatusInternalServerError, fmt.Errorf("preparing http request: %w", err)
+	if err := c.waitRateLimit(ctx); err != nil {
+		return nil, err
 	}
-	resp, err := defaultHTTPClient.Do(req)
+	// time until the response headers, logged to find the slow endpoints with TF_LOG_PROVIDER=DEBUG
+	start := time.Now()
+	resp, err := c.getHTTPClient().Do(req)
 	if err != nil {
-		return "", http.StatusInternalServerError, fmt.Errorf("sending http request: %w", err)
+		return nil, fmt.Errorf("sending http request: %w", err)
 	}
-	defer resp.Body.Close()
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", http.StatusInternalServerError, fmt.Errorf("reading http response: %w", err)
+	c.readRateLimit(resp.Header)
+	tflog.Debug(ctx, "api request", map[string]interface{}{
+		"method":     method,
+		"path":       req.URL.Path,
+		"status":     resp.StatusCode,
+		"elapsed_ms": time.Since(start).Milliseconds(),
+	})
+
+	return resp, nil
+}
+
+// readRateLimit reads the X-RateLimit-Remaining and X-RateLimit-Reset headers of a response
+// to delay the next requests until the reset when no request remains.
+// The reset can be a number of seconds or a unix timestamp.
+func (c *Client) readRateLimit(header http.Header) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil || remaining > 0 {
+		return
+	}
+	reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil || reset <= 0 {
+		return
+	}
+	resetTime := time.Unix(reset, 0)
+	if reset < 1e9 {
+		resetTime = time.Now().Add(time.Duration(reset) * time.Second)
 	}
+	c.rateLimitMutex.Lock()
+	defer c.rateLimitMutex.Unlock()
+	if resetTime.After(c.rateLimitReset) {
+		c.rateLimitReset = resetTime
+	}
+}
 
-	return string(respBody), resp.StatusCode, nil
+// waitRateLimit waits for the end of the rate limit window read by readRateLimit.
+func (c *Client) waitRateLimit(ctx context.Context) error {
+	c.rateLimitMutex.Lock()
+	wait := time.Until(c.rateLimitReset)
+	c.rateLimitMutex.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+	tflog.Debug(ctx, "api rate limit reached, waiting for the reset", map[string]interface{}{
+		"wait_ms": wait.Milliseconds(),
+	})
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for the api rate limit reset: %w", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (c *Client) getHTTPClient() *http.Client {
+	if c.httpClient != nil {
+		return c.httpClient
+	}
+
+	return defaultHTTPClient
+}
+
+// apiURL returns the full url of an api uri.
+func (c *Client) apiURL(uri string) string {
+	url := "https://" + c.bastionIP + ":" + strconv.Itoa(c.bastionPort) + "/api/" + c.bastionAPIVersion
+	if strings.HasPrefix(uri, "/") {
+		return url + uri
+	}
+
+	return url + "/" + uri
+}
+
+// unmarshalJSON decodes a json response body in v.
+// With strict_json enabled, fields of body not modeled in v are logged as warnings.
+func (c *Client) unmarshalJSON(ctx context.Context, body string, v interface{}) error {
+	if err := json.Unmarshal([]byte(body), asJSONList(v)); err != nil {
+		return err //nolint:wrapcheck
+	}
+	if c.strictJSON {
+		// decode again in a new value to not modify v with a partial decoding
+		var err error
+		if list, ok := asJSONList(v).(*jsonList); ok {
+			strict := &jsonList{items: reflect.New(reflect.TypeOf(list.items).Elem()).Interface()}
+			err = strict.decode([]byte(body), true)
+		} else {
+			strict := reflect.New(reflect.TypeOf(v).Elem()).Interface()
+			decoder := json.NewDecoder(strings.NewReader(body))
+			decoder.DisallowUnknownFields()
+			err = decoder.Decode(strict)
+		}
+		if err != nil {
+			tflog.Warn(ctx, "api returns fields not handled by the provider", map[string]interface{}{
+				"type":  fmt.Sprintf("%T", v),
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return nil
+}
+
+// jsonList decodes a list of objects returned by the api as a json array
+// or in an envelope {"items": [...], "total": N} with some appliance versions.
+type jsonList struct {
+	items interface{} // pointer to a slice
+	total *int
+}
+
+func (l *jsonList) UnmarshalJSON(data []byte) error {
+	return l.decode(data, false)
+}
+
+func (l *jsonList) decode(data []byte, disallowUnknownFields bool) error {
+	items := data
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var envelope struct {
+			Items json.RawMessage `json:"items"`
+			Total *int            `json:"total"`
+		}
+		if err := json.Unmarshal(data, &envelope); err != nil {
+			return err //nolint:wrapcheck
+		}
+		l.total = envelope.Total
+		if len(envelope.Items) == 0 {
+			return nil
+		}
+		items = envelope.Items
+	}
+	decoder := json.NewDecoder(bytes.NewReader(items))
+	if disallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+
+	return decoder.Decode(l.items) //nolint:wrapcheck
+}
+
+// asJSONList wraps v in a jsonList when it's a pointer to a slice
+// to accept the two forms of lists returned by the api.
+func asJSONList(v interface{}) interface{} {
+	if _, ok := v.(*jsonList); ok {
+		return v
+	}
+	if t := reflect.TypeOf(v); t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Slice {
+		return &jsonList{items: v}
+	}
+
+	return v
+}
+
+// unmarshalBody decodes a json response body.
+// An empty body (like the one of a NoContent response) is not an error and leaves v untouched.
+func (c *Client) unmarshalBody(ctx context.Context, body string, v interface{}) error {
+	if strings.TrimSpace(body) == "" {
+		return nil
+	}
+	if err := c.unmarshalJSON(ctx, body, v); err != nil {
+		return fmt.Errorf("unmarshaling json: %w", err)
+	}
+
+	return nil
+}
+
+// listAll gets all the objects of a list endpoint page by page with the limit and offset parameters
+// (until a page is empty or the total of an envelope is reached) and returns the raw json of each object.
+func (c *Client) listAll(ctx context.Context, uri string, query url.Values) ([]json.RawMessage, error) {
+	var objects []json.RawMessage
+	pageSize := c.listPageSize
+	if pageSize <= 0 {
+		pageSize = defaultListPageSize
+	}
+	for offset := 0; ; {
+		pageQuery := url.Values{}
+		for k, v := range query {
+			pageQuery[k] = v
+		}
+		pageQuery.Set("limit", strconv.Itoa(pageSize))
+		pageQuery.Set("offset", strconv.Itoa(offset))
+		var page []json.RawMessage
+		list := &jsonList{items: &page}
+		body, code, err := c.newRequestJSON(ctx, uri+"?"+pageQuery.Encode(), http.MethodGet, nil, list)
+		if err != nil {
+			return nil, err
+		}
+		if code != http.StatusOK {
+			return nil, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+		}
+		// an api ignoring the offset returns the first page again, don't loop on it
+		if offset > 0 && len(page) > 0 && bytes.Equal(page[0], objects[0]) {
+			return objects, nil
+		}
+		objects = append(objects, page...)
+		// a page smaller than the size requested isn't the last one when the api caps the size of the pages,
+		// the next page starts after the objects received
+		if len(page) == 0 ||
+			(list.total != nil && offset+len(page) >= *list.total) {
+			return objects, nil
+		}
+		offset += len(page)
+	}
 }
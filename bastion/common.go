@@ -1,11 +1,82 @@
 package bastion
 
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
 type jsonRestriction struct {
 	Action      string `json:"action"`
 	Rules       string `json:"rules"`
 	SubProtocol string `json:"subprotocol"`
 }
 
+// jsonBool is a boolean of the api which some appliance versions return as a string ("true", "1", ...)
+// or as a number, decoded to the same value to not have a diff between the forms.
+type jsonBool bool
+
+func (b *jsonBool) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err //nolint:wrapcheck
+	}
+	switch value := v.(type) {
+	case nil:
+		*b = false
+	case bool:
+		*b = jsonBool(value)
+	case float64:
+		*b = value != 0
+	case string:
+		parsed, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("%q isn't a boolean", value)
+		}
+		*b = jsonBool(parsed)
+	default:
+		return fmt.Errorf("%s isn't a boolean", data)
+	}
+
+	return nil
+}
+
+// jsonInt is an integer of the api which some appliance versions return as a string ("389", ...),
+// decoded to the same value to not have a diff between the forms.
+type jsonInt int
+
+func (i *jsonInt) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err //nolint:wrapcheck
+	}
+	switch value := v.(type) {
+	case nil:
+		*i = 0
+	case float64:
+		*i = jsonInt(value)
+	case string:
+		parsed, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("%q isn't an integer", value)
+		}
+		*i = jsonInt(parsed)
+	default:
+		return fmt.Errorf("%s isn't an integer", data)
+	}
+
+	return nil
+}
+
 type jsonCredential struct {
 	ID         string `json:"id,omitempty"`
 	Type       string `json:"type,omitempty"`
@@ -14,3 +85,177 @@ type jsonCredential struct {
 	PublicKey  string `json:"public_key,omitempty"`
 	Passphrase string `json:"passphrase,omitempty"`
 }
+
+// checkAllowDestroy refuses the deletion of a resource when its allow_destroy argument
+// has been set to false in the last applied configuration.
+// allow_destroy defaults to true so the configurations written before it are still destroyed as before,
+// the protection is set explicitly on the critical objects.
+func checkAllowDestroy(d *schema.ResourceData, resourceType string) error {
+	if d.Get("allow_destroy").(bool) {
+		return nil
+	}
+
+	return fmt.Errorf("%s %s is protected against deletion: "+
+		"set allow_destroy to true and apply before destroying it", resourceType, d.Id())
+}
+
+// readAfterWriteDiags downgrades errors of the read following a successful write to warnings
+// so the id and the written arguments are kept in the state (without tainting the resource).
+func readAfterWriteDiags(diags diag.Diagnostics) diag.Diagnostics {
+	for i := range diags {
+		if diags[i].Severity == diag.Error {
+			diags[i].Severity = diag.Warning
+			diags[i].Summary = "object written but reading it back failed: " + diags[i].Summary
+		}
+	}
+
+	return diags
+}
+
+// versionNotAvailableError returns the error of a resource or a data source not available with the api version
+// and lists the versions supported by the provider.
+func versionNotAvailableError(objectType, name, version string) error {
+	return fmt.Errorf("%s %s not available with api version %s (supported versions: %s)",
+		objectType, name, version, strings.Join(defaultVersionsValid(), ", "))
+}
+
+// checkPasswordChangeDiff refuses enable_password_change without password_change_policy and password_change_plugin
+// otherwise the Bastion never changes the passwords.
+func checkPasswordChangeDiff(d *schema.ResourceDiff) error {
+	if !d.Get("enable_password_change").(bool) {
+		return nil
+	}
+	for _, k := range []string{"password_change_policy", "password_change_plugin"} {
+		if d.NewValueKnown(k) && d.Get(k).(string) == "" {
+			return fmt.Errorf("%s need to be set with enable_password_change, "+
+				"otherwise passwords are never changed", k)
+		}
+	}
+
+	return nil
+}
+
+// credentialDeleteError returns the error of a refused deletion of a credential.
+// A credential still in use is refused by the Bastion with a conflict or a bad request,
+// so the error explains how to replace it when its type changes.
+func credentialDeleteError(credentialID string, code int, body string) error {
+	if code == http.StatusConflict || code == http.StatusBadRequest {
+		return fmt.Errorf("credential %s can't be deleted, it may still be in use: %d with body:\n%s\n"+
+			"a credential type can't be changed in place, but credentials of different types can exist on the "+
+			"same account: to change the type, use the create_before_destroy lifecycle argument "+
+			"to create the new credential before deleting the old one", credentialID, code, body)
+	}
+
+	return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+}
+
+// inUseDeleteError returns the error of a deletion refused with a conflict because the object is still used,
+// with the objects found using it when they are known.
+func inUseDeleteError(objectType, name string, body string, usedBy []string) error {
+	if len(usedBy) == 0 {
+		return fmt.Errorf("%s %s can't be deleted, it's still used by other objects: %d with body:\n%s\n"+
+			"remove the references to it (or delete the objects using it) before deleting it",
+			objectType, name, http.StatusConflict, body)
+	}
+
+	return fmt.Errorf("%s %s can't be deleted, it's still used by: %s\n"+
+		"remove the references to it (or delete these objects) before deleting it",
+		objectType, name, strings.Join(usedBy, ", "))
+}
+
+// checkGeneratedSSHKey returns an error when a ssh_key credential created with a generate:<ALGO> private_key
+// doesn't have a public key, i.e. the Bastion failed to generate the key.
+func checkGeneratedSSHKey(d *schema.ResourceData, cfg jsonCredential) error {
+	if d.Get("type").(string) != "ssh_key" || !strings.HasPrefix(d.Get("private_key").(string), "generate:") {
+		return nil
+	}
+	if cfg.PublicKey == "" {
+		return fmt.Errorf("credential %s created but the Bastion doesn't return a public_key for %s: "+
+			"the key generation failed, check the algorithm is allowed by the Bastion", d.Id(),
+			d.Get("private_key").(string))
+	}
+
+	return nil
+}
+
+// priorResourceData returns the data of res with the values of d before the update,
+// i.e. the values of the last read, without change.
+func priorResourceData(res *schema.Resource, d *schema.ResourceData) *schema.ResourceData {
+	prior := res.Data(nil)
+	prior.SetId(d.Id())
+	for k := range res.Schema {
+		oldValue, _ := d.GetChange(k)
+		if tfErr := prior.Set(k, oldValue); tfErr != nil {
+			panic(tfErr)
+		}
+	}
+
+	return res.Data(prior.State())
+}
+
+// sshKeyUnchanged returns true when a ssh_key credential is updated with only a change of password,
+// which isn't sent to the Bastion for this type, so the PUT (which sends the private_key again
+// and makes the Bastion change the key on the target) can be skipped.
+func sshKeyUnchanged(d *schema.ResourceData) bool {
+	return d.Get("type").(string) == "ssh_key" && !d.HasChangesExcept("password")
+}
+
+// hostnameRegexp matches host names made of labels of letters, digits, hyphens and underscores
+// (underscores are accepted by the Bastion in device names used as hosts).
+var hostnameRegexp = regexp.MustCompile( //nolint:gochecknoglobals
+	`^[a-zA-Z0-9_]([a-zA-Z0-9_-]{0,62})(\.[a-zA-Z0-9_]([a-zA-Z0-9_-]{0,62}))*\.?$`)
+
+// validateHost checks that the value is an IP address or a host name.
+func validateHost(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if net.ParseIP(v) != nil {
+		return nil, nil
+	}
+	if len(v) > 253 || !hostnameRegexp.MatchString(v) {
+		return nil, []error{fmt.Errorf("%s: %q is not an IP address or a host name", k, v)}
+	}
+
+	return nil, nil
+}
+
+// suppressHostCaseDiff ignores a change of case of a host name, host names are case insensitive.
+func suppressHostCaseDiff(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+	return strings.EqualFold(oldValue, newValue)
+}
+
+// suppressTrailingSpaceDiff ignores a change of spaces at the end of a value.
+func suppressTrailingSpaceDiff(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+	return strings.TrimRightFunc(oldValue, unicode.IsSpace) == strings.TrimRightFunc(newValue, unicode.IsSpace)
+}
+
+// suppressEquivalentJSONDiff ignores a change between two JSON strings with the same content
+// (e.g. another order of keys), an empty string, null and an empty object are the same.
+func suppressEquivalentJSONDiff(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+	decode := func(value string) (interface{}, bool) {
+		if strings.TrimSpace(value) == "" {
+			value = "{}"
+		}
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+			return nil, false
+		}
+		if decoded == nil {
+			decoded = make(map[string]interface{})
+		}
+
+		return decoded, true
+	}
+	oldDecoded, ok := decode(oldValue)
+	if !ok {
+		return false
+	}
+	newDecoded, ok := decode(newValue)
+	if !ok {
+		return false
+	}
+
+	return reflect.DeepEqual(oldDecoded, newDecoded)
+}
@@ -1,21 +1,45 @@
 package bastion
 
 import (
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
 // Config: provider config.
 type Config struct {
 	bastionPort       int
+	listPageSize      int
 	bastionAPIVersion string
 	bastionIP         string
 	bastionToken      string
 	bastionUser       string
 	bastionPwd        string
+	strictJSON        bool
+	proxyURL          string
+	minTLSVersion     string
+	ignoreFields      []string
+}
+
+// TLS versions accepted by the min_tls_version argument of the provider.
+func tlsVersions() map[string]uint16 {
+	return map[string]uint16{
+		"1.0": tls.VersionTLS10,
+		"1.1": tls.VersionTLS11,
+		"1.2": tls.VersionTLS12,
+		"1.3": tls.VersionTLS13,
+	}
 }
 
 // Client: read information to connect on wallix bastion.
 func (c *Config) Client() (*Client, diag.Diagnostics) {
+	if diags := c.validateAPIVersion(); diags.HasError() {
+		return nil, diags
+	}
 	cl := &Client{
 		bastionIP:         c.bastionIP,
 		bastionPort:       c.bastionPort,
@@ -23,7 +47,53 @@ func (c *Config) Client() (*Client, diag.Diagnostics) {
 		bastionUser:       c.bastionUser,
 		bastionAPIVersion: c.bastionAPIVersion,
 		bastionPwd:        c.bastionPwd,
+		strictJSON:        c.strictJSON,
+		listPageSize:      c.listPageSize,
+		ignoreFields:      c.ignoreFields,
+	}
+	minTLSVersion := uint16(tls.VersionTLS12)
+	if c.minTLSVersion != "" {
+		v, ok := tlsVersions()[c.minTLSVersion]
+		if !ok {
+			return nil, diag.FromErr(fmt.Errorf("min_tls_version %s isn't a TLS version", c.minTLSVersion))
+		}
+		minTLSVersion = v
+	}
+	var proxyURL *url.URL
+	if c.proxyURL != "" {
+		var err error
+		proxyURL, err = url.Parse(c.proxyURL)
+		if err != nil {
+			return nil, diag.FromErr(fmt.Errorf("parsing proxy_url: %w", err))
+		}
+	}
+	if proxyURL != nil || minTLSVersion != tls.VersionTLS12 {
+		cl.httpClient = newHTTPClient(proxyURL, minTLSVersion)
 	}
 
 	return cl, nil
 }
+
+// validateAPIVersion checks once for the provider that the api version is usable
+// rather than letting each resource fail with its own version check.
+func (c *Config) validateAPIVersion() diag.Diagnostics {
+	accepted := strings.Join(defaultVersionsValid(), ", ")
+	if c.bastionAPIVersion == "" {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "could not determine API version; set api_version explicitly",
+			Detail: "the api_version argument of the provider (or the WALLIX_BASTION_API_VERSION environment variable) " +
+				"is empty, accepted values are " + accepted,
+		}}
+	}
+	if !slices.Contains(defaultVersionsValid(), c.bastionAPIVersion) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("API version %s is not supported; set api_version explicitly", c.bastionAPIVersion),
+			Detail: "the api_version argument of the provider (or the WALLIX_BASTION_API_VERSION environment variable) " +
+				"need to be one of " + accepted,
+		}}
+	}
+
+	return nil
+}
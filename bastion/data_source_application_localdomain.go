@@ -0,0 +1,100 @@
+package bastion
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceApplicationLocalDomain() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceApplicationLocalDomainRead,
+		Schema: map[string]*schema.Schema{
+			"application_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"application_id", "application_name"},
+			},
+			"application_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"domain_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"admin_account": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"enable_password_change": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"password_change_policy": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"password_change_plugin": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceApplicationLocalDomainVersionCheck(version string) error {
+	if slices.Contains(defaultVersionsValid(), version) {
+		return nil
+	}
+
+	return versionNotAvailableError("data source", "wallix-bastion_application_localdomain", version)
+}
+
+func dataSourceApplicationLocalDomainRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := dataSourceApplicationLocalDomainVersionCheck(c.bastionAPIVersion); err != nil {
+		return diag.FromErr(err)
+	}
+	applicationID := d.Get("application_id").(string)
+	if v := d.Get("application_name").(string); v != "" {
+		id, ex, err := searchResourceApplication(ctx, v, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !ex {
+			return diag.FromErr(fmt.Errorf("application_name %s doesn't exists", v))
+		}
+		applicationID = id
+	}
+	domainName := d.Get("domain_name").(string)
+	id, ex, err := searchResourceApplicationLocalDomain(ctx, applicationID, domainName, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !ex {
+		return diag.FromErr(fmt.Errorf("domain_name %s on application_id %s doesn't exists", domainName, applicationID))
+	}
+	cfg, err := readApplicationLocalDomainOptions(ctx, applicationID, id, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// password_change_plugin_parameters is sensitive and not in the data source schema
+	fillApplicationLocalDomain(d, cfg)
+	if tfErr := d.Set("application_id", applicationID); tfErr != nil {
+		panic(tfErr)
+	}
+	d.SetId(id)
+
+	return nil
+}
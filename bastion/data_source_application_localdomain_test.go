@@ -0,0 +1,69 @@
+package bastion_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceApplicationLocalDomain_read(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/applications/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/" + bastion.VersionWallixAPI312 + "/applications/":
+			if r.URL.Query().Get("q") != "application_name=app" {
+				_, _ = w.Write([]byte(`[]`))
+
+				return
+			}
+			_, _ = w.Write([]byte(`[{"id":"app-id","application_name":"app"}]`))
+		case "/api/" + bastion.VersionWallixAPI312 + "/applications/app-id/localdomains/":
+			if r.URL.Query().Get("q") != "domain_name=local" {
+				_, _ = w.Write([]byte(`[]`))
+
+				return
+			}
+			_, _ = w.Write([]byte(`[{"id":"dom-id","domain_name":"local"}]`))
+		case "/api/" + bastion.VersionWallixAPI312 + "/applications/app-id/localdomains/dom-id":
+			_, _ = w.Write([]byte(`{"id":"dom-id","domain_name":"local","admin_account":"admin",` +
+				`"enable_password_change":true,"password_change_policy":"default","password_change_plugin":"Windows"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	provider := testProviderWithServer(t, mux)
+	ds := provider.DataSourcesMap["wallix-bastion_application_localdomain"]
+
+	for _, raw := range []map[string]interface{}{
+		{"application_id": "app-id", "domain_name": "local"},
+		{"application_name": "app", "domain_name": "local"},
+	} {
+		d := schema.TestResourceDataRaw(t, ds.Schema, raw)
+		if diags := ds.ReadContext(context.Background(), d, provider.Meta()); diags.HasError() {
+			t.Fatalf("read with %v: %v", raw, diags)
+		}
+		if d.Id() != "dom-id" {
+			t.Errorf("id = %q, want %q", d.Id(), "dom-id")
+		}
+		if v := d.Get("application_id").(string); v != "app-id" {
+			t.Errorf("application_id = %q, want %q", v, "app-id")
+		}
+		if v := d.Get("admin_account").(string); v != "admin" {
+			t.Errorf("admin_account = %q, want %q", v, "admin")
+		}
+	}
+
+	for _, raw := range []map[string]interface{}{
+		{"application_name": "missing", "domain_name": "local"},
+		{"application_id": "app-id", "domain_name": "missing"},
+	} {
+		d := schema.TestResourceDataRaw(t, ds.Schema, raw)
+		if diags := ds.ReadContext(context.Background(), d, provider.Meta()); !diags.HasError() {
+			t.Errorf("read with %v doesn't return an error", raw)
+		}
+	}
+}
@@ -52,7 +52,7 @@ func dataSourceAuthDomainAdVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("data source wallix-bastion_authdomain not available with api version %s", version)
+	return versionNotAvailableError("data source", "wallix-bastion_authdomain_ad", version)
 }
 
 func dataSourceAuthDomainADRead(
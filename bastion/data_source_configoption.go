@@ -61,7 +61,7 @@ func dataSourceConfigoptionVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("data source wallix-bastion_configoption not available with api version %s", version)
+	return versionNotAvailableError("data source", "wallix-bastion_configoption", version)
 }
 
 func dataSourceConfigoptionRead(
@@ -103,7 +103,7 @@ func readConfigoption(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
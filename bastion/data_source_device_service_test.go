@@ -0,0 +1,73 @@
+package bastion_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDeviceService_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDeviceServiceConfigCreate(),
+			},
+			{
+				Config: testAccDataSourceDeviceServiceConfigData(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.wallix-bastion_device_service.testacc_dataDeviceService", "id",
+						"wallix-bastion_device_service.testacc_dataDeviceService", "id"),
+					resource.TestCheckResourceAttr("data.wallix-bastion_device_service.testacc_dataDeviceService",
+						"protocol", "SSH"),
+					resource.TestCheckResourceAttr("data.wallix-bastion_device_service.testacc_dataDeviceService",
+						"port", "22"),
+				),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccDataSourceDeviceServiceConfigCreate() string {
+	return `
+resource "wallix-bastion_device" "testacc_dataDeviceService" {
+  device_name = "testacc_dataDeviceService"
+  host        = "testacc_datadeviceservice"
+}
+
+resource "wallix-bastion_device_service" "testacc_dataDeviceService" {
+  device_id         = wallix-bastion_device.testacc_dataDeviceService.id
+  service_name      = "testacc_dataDeviceService"
+  connection_policy = "SSH"
+  port              = 22
+  protocol          = "SSH"
+  subprotocols      = ["SSH_SHELL_SESSION"]
+}
+`
+}
+
+func testAccDataSourceDeviceServiceConfigData() string {
+	return `
+resource "wallix-bastion_device" "testacc_dataDeviceService" {
+  device_name = "testacc_dataDeviceService"
+  host        = "testacc_datadeviceservice"
+}
+
+resource "wallix-bastion_device_service" "testacc_dataDeviceService" {
+  device_id         = wallix-bastion_device.testacc_dataDeviceService.id
+  service_name      = "testacc_dataDeviceService"
+  connection_policy = "SSH"
+  port              = 22
+  protocol          = "SSH"
+  subprotocols      = ["SSH_SHELL_SESSION"]
+}
+
+data "wallix-bastion_device_service" "testacc_dataDeviceService" {
+  device_id    = wallix-bastion_device.testacc_dataDeviceService.id
+  service_name = "testacc_dataDeviceService"
+}
+`
+}
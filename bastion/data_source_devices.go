@@ -0,0 +1,135 @@
+package bastion
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDevices() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDevicesRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"expand": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"devices": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"device_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"host": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"alias": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"local_domains": resourceDevice().Schema["local_domains"],
+						"services":      resourceDevice().Schema["services"],
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDevicesVersionCheck(version string) error {
+	if slices.Contains(defaultVersionsValid(), version) {
+		return nil
+	}
+
+	return versionNotAvailableError("data source", "wallix-bastion_devices", version)
+}
+
+func dataSourceDevicesRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := dataSourceDevicesVersionCheck(c.bastionAPIVersion); err != nil {
+		return diag.FromErr(err)
+	}
+	query := url.Values{}
+	if v := d.Get("filter").(string); v != "" {
+		query.Set("q", v)
+	}
+	devices, err := listDevices(ctx, query, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	fillSourceDevices(d, devices, d.Get("expand").(bool))
+	d.SetId("devices?" + query.Encode())
+
+	return nil
+}
+
+func listDevices(
+	ctx context.Context, query url.Values, m interface{},
+) (
+	[]jsonDevice, error,
+) {
+	c := m.(*Client)
+	objects, err := c.listAll(ctx, "/devices/", query)
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]jsonDevice, len(objects))
+	for i, v := range objects {
+		if err := c.unmarshalJSON(ctx, string(v), &devices[i]); err != nil {
+			return nil, fmt.Errorf("unmarshaling json: %w", err)
+		}
+	}
+
+	return devices, nil
+}
+
+// fillSourceDevices sets the devices, with their local domains and services
+// (returned with each device of the list by the api) only when expand is true.
+func fillSourceDevices(d *schema.ResourceData, devices []jsonDevice, expand bool) {
+	list := make([]map[string]interface{}, len(devices))
+	for i, v := range devices {
+		list[i] = map[string]interface{}{
+			"id":            v.ID,
+			"device_name":   v.DeviceName,
+			"host":          v.Host,
+			"alias":         v.Alias,
+			"description":   v.Description,
+			"local_domains": make([]map[string]interface{}, 0),
+			"services":      make([]map[string]interface{}, 0),
+		}
+		if expand {
+			list[i]["local_domains"] = flattenDeviceLocalDomains(v.LocalDomains)
+			list[i]["services"] = flattenDeviceServices(v.Services)
+		}
+	}
+	if tfErr := d.Set("devices", list); tfErr != nil {
+		panic(tfErr)
+	}
+}
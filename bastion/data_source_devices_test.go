@@ -0,0 +1,112 @@
+package bastion_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccDataSourceDevices_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDevicesConfigCreate(),
+			},
+			{
+				Config: testAccDataSourceDevicesConfigCreate() + `
+data "wallix-bastion_devices" "testacc_dataDevices" {
+  filter = "device_name=testacc_dataDevices"
+  expand = true
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.wallix-bastion_devices.testacc_dataDevices",
+						"devices.#", "1"),
+					resource.TestCheckResourceAttr("data.wallix-bastion_devices.testacc_dataDevices",
+						"devices.0.services.0.service_name", "testacc_dataDevices"),
+				),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+// TestDataSourceDevices_expand checks that all the pages are read
+// and that services are only set with expand.
+func TestDataSourceDevices_expand(t *testing.T) {
+	const total = 130
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/devices/", func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		devices := make([]map[string]interface{}, 0)
+		for i := offset; i < total && i < offset+limit; i++ {
+			devices = append(devices, map[string]interface{}{
+				"id":          strconv.Itoa(i),
+				"device_name": fmt.Sprintf("device%d", i),
+				"host":        fmt.Sprintf("device%d.none", i),
+				"services": []map[string]interface{}{{
+					"service_name":      "SSH",
+					"connection_policy": "SSH",
+					"protocol":          "SSH",
+					"port":              22,
+				}},
+				"local_domains": []map[string]interface{}{{"domain_name": "local"}},
+			})
+		}
+		_ = json.NewEncoder(w).Encode(devices)
+	})
+	provider := testProviderWithServer(t, mux)
+	ds := provider.DataSourcesMap["wallix-bastion_devices"]
+	for _, expand := range []bool{false, true} {
+		d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+			"expand": expand,
+		})
+		if diags := ds.ReadContext(context.Background(), d, provider.Meta()); diags.HasError() {
+			t.Fatalf("read: %v", diags)
+		}
+		if v := d.Get("devices.#").(int); v != total {
+			t.Errorf("devices.# = %d, want %d", v, total)
+		}
+		if v := d.Get("devices.129.host").(string); v != "device129.none" {
+			t.Errorf("devices.129.host = %q, want %q", v, "device129.none")
+		}
+		want := 0
+		if expand {
+			want = 1
+		}
+		if v := d.Get("devices.0.services.#").(int); v != want {
+			t.Errorf("expand = %t: devices.0.services.# = %d, want %d", expand, v, want)
+		}
+		if v := d.Get("devices.0.local_domains.#").(int); v != want {
+			t.Errorf("expand = %t: devices.0.local_domains.# = %d, want %d", expand, v, want)
+		}
+	}
+}
+
+func testAccDataSourceDevicesConfigCreate() string {
+	return `
+resource "wallix-bastion_device" "testacc_dataDevices" {
+  device_name = "testacc_dataDevices"
+  host        = "testacc_datadevices"
+}
+resource "wallix-bastion_device_service" "testacc_dataDevices" {
+  device_id         = wallix-bastion_device.testacc_dataDevices.id
+  service_name      = "testacc_dataDevices"
+  connection_policy = "SSH"
+  port              = 22
+  protocol          = "SSH"
+  subprotocols      = ["SSH_SHELL_SESSION"]
+}
+`
+}
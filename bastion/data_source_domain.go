@@ -58,7 +58,7 @@ func dataSourceDomainVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("data source wallix-bastion_domain not available with api version %s", version)
+	return versionNotAvailableError("data source", "wallix-bastion_domain", version)
 }
 
 func dataSourceDomainRead(
@@ -0,0 +1,119 @@
+package bastion
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceExternalAuthLdap() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceExternalAuthLdapRead,
+		Schema: map[string]*schema.Schema{
+			"authentication_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"ca_certificate": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cn_attribute": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"host": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"is_active_directory": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"is_anonymous_access": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"is_protected_user": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"is_ssl": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"is_starttls": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"ldap_base": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"login": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"login_attribute": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"port": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"timeout": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"use_primary_auth_domain": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceExternalAuthLdapVersionCheck(version string) error {
+	if slices.Contains(defaultVersionsValid(), version) {
+		return nil
+	}
+
+	return versionNotAvailableError("data source", "wallix-bastion_externalauth_ldap", version)
+}
+
+func dataSourceExternalAuthLdapRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := dataSourceExternalAuthLdapVersionCheck(c.bastionAPIVersion); err != nil {
+		return diag.FromErr(err)
+	}
+	id, ex, err := searchResourceExternalAuthLdap(ctx, d.Get("authentication_name").(string), m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !ex {
+		return diag.FromErr(fmt.Errorf("authentication_name %s doesn't exists", d.Get("authentication_name").(string)))
+	}
+	cfg, err := readExternalAuthLdapOptions(ctx, id, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if cfg.Type != "LDAP" {
+		return diag.FromErr(fmt.Errorf("authentication_name %s is an external authentication of type %s, not LDAP",
+			d.Get("authentication_name").(string), cfg.Type))
+	}
+	// the sensitive fields (certificate, password, private key) aren't in the data source schema
+	fillExternalAuthLdap(d, cfg)
+	d.SetId(id)
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package bastion_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceExternalAuthLdap_read(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/externalauths/", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/"+bastion.VersionWallixAPI312+"/externalauths/ldap-id":
+			_, _ = w.Write([]byte(`{"id":"ldap-id","authentication_name":"corp","type":"LDAP","host":"ldap.corp",` +
+				`"port":636,"is_ssl":true,"timeout":10,"ldap_base":"dc=corp","login":"cn=bind",` +
+				`"ca_certificate":"-----BEGIN CERTIFICATE-----","password":"secret"}`))
+		case r.URL.Path == "/api/"+bastion.VersionWallixAPI312+"/externalauths/radius-id":
+			_, _ = w.Write([]byte(`{"id":"radius-id","authentication_name":"otp","type":"RADIUS"}`))
+		case r.URL.Query().Get("q") == "authentication_name=corp":
+			_, _ = w.Write([]byte(`[{"id":"ldap-id","authentication_name":"corp"}]`))
+		case r.URL.Query().Get("q") == "authentication_name=otp":
+			_, _ = w.Write([]byte(`[{"id":"radius-id","authentication_name":"otp"}]`))
+		default:
+			_, _ = w.Write([]byte(`[]`))
+		}
+	})
+	provider := testProviderWithServer(t, mux)
+	ds := provider.DataSourcesMap["wallix-bastion_externalauth_ldap"]
+
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"authentication_name": "corp",
+	})
+	if diags := ds.ReadContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Id() != "ldap-id" {
+		t.Errorf("id = %q, want %q", d.Id(), "ldap-id")
+	}
+	if v := d.Get("ca_certificate").(string); v != "-----BEGIN CERTIFICATE-----" {
+		t.Errorf("ca_certificate = %q, want the certificate returned by the api", v)
+	}
+	if v := d.Get("port").(int); v != 636 {
+		t.Errorf("port = %d, want 636", v)
+	}
+
+	for _, name := range []string{"otp", "missing"} {
+		d = schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+			"authentication_name": name,
+		})
+		if diags := ds.ReadContext(context.Background(), d, provider.Meta()); !diags.HasError() {
+			t.Errorf("read of %s doesn't return an error", name)
+		}
+	}
+}
+
+// TestDataSourceExternalAuthLdap_stringForms checks that booleans and integers returned as strings
+// by some appliance versions are read as the same values.
+func TestDataSourceExternalAuthLdap_stringForms(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/externalauths/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/"+bastion.VersionWallixAPI312+"/externalauths/ldap-id" {
+			_, _ = w.Write([]byte(`{"id":"ldap-id","authentication_name":"corp","type":"LDAP","host":"ldap.corp",` +
+				`"port":"389","is_ssl":"1","is_starttls":"false","use_primary_auth_domain":"true",` +
+				`"is_active_directory":0,"timeout":10}`))
+
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id":"ldap-id","authentication_name":"corp"}]`))
+	})
+	provider := testProviderWithServer(t, mux)
+	ds := provider.DataSourcesMap["wallix-bastion_externalauth_ldap"]
+
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"authentication_name": "corp",
+	})
+	if diags := ds.ReadContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if v := d.Get("port").(int); v != 389 {
+		t.Errorf("port = %d, want 389", v)
+	}
+	if !d.Get("is_ssl").(bool) || d.Get("is_starttls").(bool) || !d.Get("use_primary_auth_domain").(bool) ||
+		d.Get("is_active_directory").(bool) {
+		t.Errorf("booleans = is_ssl %v, is_starttls %v, use_primary_auth_domain %v, is_active_directory %v, "+
+			"want true, false, true, false", d.Get("is_ssl"), d.Get("is_starttls"),
+			d.Get("use_primary_auth_domain"), d.Get("is_active_directory"))
+	}
+}
@@ -2,10 +2,10 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
+	"net/url"
 	"slices"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -101,7 +101,7 @@ func dataSourceLocalPasswordPolicyVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("data source wallix-bastion_local_password_policy not available with api version %s", version)
+	return versionNotAvailableError("data source", "wallix-bastion_local_password_policy", version)
 }
 
 func dataSourceLocalPasswordPolicyRead(
@@ -121,30 +121,36 @@ func dataSourceLocalPasswordPolicyRead(
 	return nil
 }
 
+// readLocalPasswordPolicyOptions returns the policy named exactly passwordPolicyName
+// among all the pages of results of the search, which can also match other names.
 func readLocalPasswordPolicyOptions(
 	ctx context.Context, passwordPolicyName string, m interface{},
 ) (
 	jsonLocalPasswordPolicy, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx,
-		"/localpasswordpolicies/?q=password_policy_name="+passwordPolicyName, http.MethodGet, nil)
+	objects, err := c.listAll(ctx, "/localpasswordpolicies/",
+		url.Values{"q": {"password_policy_name=" + passwordPolicyName}})
 	if err != nil {
 		return jsonLocalPasswordPolicy{}, err
 	}
-	if code != http.StatusOK {
-		return jsonLocalPasswordPolicy{}, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
-	}
-	var results []jsonLocalPasswordPolicy
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return jsonLocalPasswordPolicy{}, fmt.Errorf("unmarshaling json: %w", err)
-	}
-	if len(results) == 0 {
-		return jsonLocalPasswordPolicy{}, fmt.Errorf("password_policy_name %s not found", passwordPolicyName)
+	otherNames := make([]string, 0, len(objects))
+	for _, v := range objects {
+		var result jsonLocalPasswordPolicy
+		if err := c.unmarshalJSON(ctx, string(v), &result); err != nil {
+			return jsonLocalPasswordPolicy{}, fmt.Errorf("unmarshaling json: %w", err)
+		}
+		if result.PasswordPolicyName == passwordPolicyName {
+			return result, nil
+		}
+		otherNames = append(otherNames, result.PasswordPolicyName)
+	}
+	if len(otherNames) > 0 {
+		return jsonLocalPasswordPolicy{}, fmt.Errorf("password_policy_name %s not found, only found %s",
+			passwordPolicyName, strings.Join(otherNames, ", "))
 	}
 
-	return results[0], nil
+	return jsonLocalPasswordPolicy{}, fmt.Errorf("password_policy_name %s not found", passwordPolicyName)
 }
 
 func fillLocalPasswordPolicy(d *schema.ResourceData, jsonData jsonLocalPasswordPolicy) {
@@ -0,0 +1,48 @@
+package bastion
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceLocalPasswordPolicyDefault() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceLocalPasswordPolicyDefaultRead,
+		Schema: map[string]*schema.Schema{
+			"password_policy_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceLocalPasswordPolicyDefaultVersionCheck(version string) error {
+	if slices.Contains(defaultVersionsValid(), version) {
+		return nil
+	}
+
+	return versionNotAvailableError("data source", "wallix-bastion_local_password_policy_default", version)
+}
+
+func dataSourceLocalPasswordPolicyDefaultRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := dataSourceLocalPasswordPolicyDefaultVersionCheck(c.bastionAPIVersion); err != nil {
+		return diag.FromErr(err)
+	}
+	cfg, err := readLocalPasswordPolicyOptions(ctx, "default", m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if tfErr := d.Set("password_policy_name", cfg.PasswordPolicyName); tfErr != nil {
+		panic(tfErr)
+	}
+	d.SetId(cfg.ID)
+
+	return nil
+}
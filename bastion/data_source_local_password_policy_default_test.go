@@ -0,0 +1,35 @@
+package bastion_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceLocalPasswordPolicyDefault_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceLocalPasswordPolicyDefaultData(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.wallix-bastion_local_password_policy_default.default", "id",
+						"data.wallix-bastion_local_password_policy.default", "id"),
+					resource.TestCheckResourceAttr("data.wallix-bastion_local_password_policy_default.default",
+						"password_policy_name", "default"),
+				),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccDataSourceLocalPasswordPolicyDefaultData() string {
+	return `
+data "wallix-bastion_local_password_policy" "default" {}
+
+data "wallix-bastion_local_password_policy_default" "default" {}
+`
+}
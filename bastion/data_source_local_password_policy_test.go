@@ -1,11 +1,53 @@
 package bastion_test
 
 import (
+	"context"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// TestDataSourceLocalPasswordPolicy_exactName checks that a policy with a name starting with the searched name
+// is not returned in place of the policy with the exact name.
+func TestDataSourceLocalPasswordPolicy_exactName(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/localpasswordpolicies/",
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("q") == "password_policy_name=default" {
+				_, _ = w.Write([]byte(`[{"id":"2","password_policy_name":"default2"},` +
+					`{"id":"1","password_policy_name":"default"}]`))
+
+				return
+			}
+			_, _ = w.Write([]byte(`[{"id":"3","password_policy_name":"strict2"}]`))
+		})
+	provider := testProviderWithServer(t, mux)
+	ds := provider.DataSourcesMap["wallix-bastion_local_password_policy"]
+
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"password_policy_name": "default",
+	})
+	if diags := ds.ReadContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Id() != "1" {
+		t.Errorf("id = %q, want %q", d.Id(), "1")
+	}
+
+	d = schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"password_policy_name": "strict",
+	})
+	diags := ds.ReadContext(context.Background(), d, provider.Meta())
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "strict2") {
+		t.Errorf("expected an error listing the other policy found, got %v", diags)
+	}
+}
+
 func TestAccDataSourceLocalPasswordPolicy_basic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
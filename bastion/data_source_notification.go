@@ -0,0 +1,127 @@
+package bastion
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type jsonNotification struct {
+	Enabled          bool     `json:"enabled"`
+	ID               string   `json:"id"`
+	NotificationName string   `json:"notification_name"`
+	Description      string   `json:"description"`
+	Destination      string   `json:"destination"`
+	Language         string   `json:"language"`
+	Type             string   `json:"type"`
+	Events           []string `json:"events"`
+}
+
+func dataSourceNotification() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNotificationRead,
+		Schema: map[string]*schema.Schema{
+			"notification_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"destination": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"events": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"language": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceNotificationVersionCheck(version string) error {
+	if slices.Contains(defaultVersionsValid(), version) {
+		return nil
+	}
+
+	return versionNotAvailableError("data source", "wallix-bastion_notification", version)
+}
+
+func dataSourceNotificationRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := dataSourceNotificationVersionCheck(c.bastionAPIVersion); err != nil {
+		return diag.FromErr(err)
+	}
+	cfg, err := readNotificationOptions(ctx, d.Get("notification_name").(string), m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	fillNotification(d, cfg)
+	d.SetId(cfg.ID)
+
+	return nil
+}
+
+func readNotificationOptions(
+	ctx context.Context, notificationName string, m interface{},
+) (
+	jsonNotification, error,
+) {
+	c := m.(*Client)
+	var results []jsonNotification
+	body, code, err := c.newRequestJSON(ctx,
+		"/notifications/?q=notification_name="+notificationName, http.MethodGet, nil, &results)
+	if err != nil {
+		return jsonNotification{}, err
+	}
+	if code != http.StatusOK {
+		return jsonNotification{}, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+	}
+	if len(results) == 0 {
+		return jsonNotification{}, fmt.Errorf("notification_name %s not found", notificationName)
+	}
+
+	return results[0], nil
+}
+
+func fillNotification(d *schema.ResourceData, jsonData jsonNotification) {
+	if tfErr := d.Set("description", jsonData.Description); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("destination", jsonData.Destination); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("enabled", jsonData.Enabled); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("events", jsonData.Events); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("language", jsonData.Language); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("type", jsonData.Type); tfErr != nil {
+		panic(tfErr)
+	}
+}
@@ -0,0 +1,49 @@
+package bastion_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceNotification_read(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/notifications/", func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query().Get("q"); q != "notification_name=audit" {
+			_, _ = w.Write([]byte(`[]`))
+
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id":"1","notification_name":"audit","enabled":true,"type":"email",` +
+			`"destination":"audit@none.none","language":"en","events":["daily_reporting","watchdog"]}]`))
+	})
+	provider := testProviderWithServer(t, mux)
+	ds := provider.DataSourcesMap["wallix-bastion_notification"]
+
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"notification_name": "audit",
+	})
+	if diags := ds.ReadContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Id() != "1" {
+		t.Errorf("id = %q, want %q", d.Id(), "1")
+	}
+	if v := d.Get("destination").(string); v != "audit@none.none" {
+		t.Errorf("destination = %q, want %q", v, "audit@none.none")
+	}
+	if v := d.Get("events").(*schema.Set); v.Len() != 2 || !v.Contains("watchdog") {
+		t.Errorf("events = %v, want [daily_reporting watchdog]", v.List())
+	}
+
+	d = schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"notification_name": "missing",
+	})
+	if diags := ds.ReadContext(context.Background(), d, provider.Meta()); !diags.HasError() {
+		t.Errorf("read of a missing notification doesn't return an error")
+	}
+}
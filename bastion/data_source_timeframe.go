@@ -0,0 +1,87 @@
+package bastion
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceTimeframe() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTimeframeRead,
+		Schema: map[string]*schema.Schema{
+			"timeframe_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"is_overtimable": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"periods": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"end_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"start_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"end_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"week_days": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTimeframeVersionCheck(version string) error {
+	if slices.Contains(defaultVersionsValid(), version) {
+		return nil
+	}
+
+	return versionNotAvailableError("data source", "wallix-bastion_timeframe", version)
+}
+
+func dataSourceTimeframeRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := dataSourceTimeframeVersionCheck(c.bastionAPIVersion); err != nil {
+		return diag.FromErr(err)
+	}
+	cfg, err := readTimeframeOptions(ctx, d.Get("timeframe_name").(string), m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if cfg.TimeframeName == "" {
+		return diag.FromErr(fmt.Errorf("timeframe_name %s doesn't exists", d.Get("timeframe_name").(string)))
+	}
+	fillTimeframe(d, cfg)
+	d.SetId(cfg.TimeframeName)
+
+	return nil
+}
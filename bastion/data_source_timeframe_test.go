@@ -0,0 +1,55 @@
+package bastion_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceTimeframe_read(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/timeframes/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/"+bastion.VersionWallixAPI312+"/timeframes/workhours" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+		_, _ = w.Write([]byte(`{"timeframe_name":"workhours","is_overtimable":true,"periods":[` +
+			`{"start_date":"2026-01-01","end_date":"2026-12-31","start_time":"08:00","end_time":"18:00",` +
+			`"week_days":["monday","friday"]}]}`))
+	})
+	provider := testProviderWithServer(t, mux)
+	ds := provider.DataSourcesMap["wallix-bastion_timeframe"]
+
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"timeframe_name": "workhours",
+	})
+	if diags := ds.ReadContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Id() != "workhours" {
+		t.Errorf("id = %q, want %q", d.Id(), "workhours")
+	}
+	if !d.Get("is_overtimable").(bool) {
+		t.Error("is_overtimable = false, want true")
+	}
+	periods := d.Get("periods").(*schema.Set).List()
+	if len(periods) != 1 {
+		t.Fatalf("periods = %v, want 1 period", periods)
+	}
+	period := periods[0].(map[string]interface{})
+	if period["start_time"] != "08:00" || period["week_days"].(*schema.Set).Len() != 2 {
+		t.Errorf("period = %v, want 08:00 on monday and friday", period)
+	}
+
+	d = schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"timeframe_name": "missing",
+	})
+	if diags := ds.ReadContext(context.Background(), d, provider.Meta()); !diags.HasError() {
+		t.Errorf("read of a missing timeframe doesn't return an error")
+	}
+}
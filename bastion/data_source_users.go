@@ -0,0 +1,186 @@
+package bastion
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceUsers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceUsersRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"expand": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"users": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"user_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"display_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"profile": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_disabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"user_auths": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"groups": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"authorizations": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceUsersVersionCheck(version string) error {
+	if slices.Contains(defaultVersionsValid(), version) {
+		return nil
+	}
+
+	return versionNotAvailableError("data source", "wallix-bastion_users", version)
+}
+
+func dataSourceUsersRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := dataSourceUsersVersionCheck(c.bastionAPIVersion); err != nil {
+		return diag.FromErr(err)
+	}
+	query := url.Values{}
+	if v := d.Get("filter").(string); v != "" {
+		query.Set("q", v)
+	}
+	users, err := listUsers(ctx, query, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var userGroupsAuthorizations map[string][]string
+	if d.Get("expand").(bool) {
+		userGroupsAuthorizations, err = listAuthorizationsByUserGroup(ctx, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	fillSourceUsers(d, users, userGroupsAuthorizations)
+	d.SetId("users?" + query.Encode())
+
+	return nil
+}
+
+func listUsers(
+	ctx context.Context, query url.Values, m interface{},
+) (
+	[]jsonUser, error,
+) {
+	c := m.(*Client)
+	objects, err := c.listAll(ctx, "/users/", query)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]jsonUser, len(objects))
+	for i, v := range objects {
+		if err := c.unmarshalJSON(ctx, string(v), &users[i]); err != nil {
+			return nil, fmt.Errorf("unmarshaling json: %w", err)
+		}
+	}
+
+	return users, nil
+}
+
+// listAuthorizationsByUserGroup returns the names of the authorizations for each user group.
+func listAuthorizationsByUserGroup(
+	ctx context.Context, m interface{},
+) (
+	map[string][]string, error,
+) {
+	c := m.(*Client)
+	objects, err := c.listAll(ctx, "/authorizations/", nil)
+	if err != nil {
+		return nil, err
+	}
+	authorizations := make(map[string][]string)
+	for _, v := range objects {
+		var authorization jsonAuthorization
+		if err := c.unmarshalJSON(ctx, string(v), &authorization); err != nil {
+			return nil, fmt.Errorf("unmarshaling json: %w", err)
+		}
+		authorizations[authorization.UserGroup] = append(authorizations[authorization.UserGroup],
+			authorization.AuthorizationName)
+	}
+
+	return authorizations, nil
+}
+
+func fillSourceUsers(d *schema.ResourceData, users []jsonUser, userGroupsAuthorizations map[string][]string) {
+	list := make([]map[string]interface{}, len(users))
+	for i, v := range users {
+		groups := make([]string, 0)
+		if v.Groups != nil {
+			groups = *v.Groups
+		}
+		authorizations := make([]string, 0)
+		for _, group := range groups {
+			for _, authorization := range userGroupsAuthorizations[group] {
+				if !slices.Contains(authorizations, authorization) {
+					authorizations = append(authorizations, authorization)
+				}
+			}
+		}
+		list[i] = map[string]interface{}{
+			"user_name":      v.UserName,
+			"display_name":   v.DisplayName,
+			"email":          v.Email,
+			"profile":        v.Profile,
+			"is_disabled":    v.IsDisabled,
+			"user_auths":     v.UserAuths,
+			"groups":         groups,
+			"authorizations": authorizations,
+		}
+	}
+	if tfErr := d.Set("users", list); tfErr != nil {
+		panic(tfErr)
+	}
+}
@@ -0,0 +1,212 @@
+package bastion_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccDataSourceUsers_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceUsersConfigCreate(),
+			},
+			{
+				Config: testAccDataSourceUsersConfigData(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.wallix-bastion_users.testacc_dataUsers",
+						"users.#", "1"),
+					resource.TestCheckResourceAttr("data.wallix-bastion_users.testacc_dataUsers",
+						"users.0.groups.0", "testacc_dataUsers"),
+					resource.TestCheckResourceAttr("data.wallix-bastion_users.testacc_dataUsers",
+						"users.0.authorizations.#", "0"),
+				),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+// TestDataSourceUsers_paginated checks that all the pages are read with the filter
+// and that authorizations are expanded from the groups of each user.
+func TestDataSourceUsers_paginated(t *testing.T) {
+	const total = 150
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/users/", func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if q := r.URL.Query().Get("q"); q != "profile=user" {
+			t.Errorf("page at offset %d requested with q=%q, want %q", offset, q, "profile=user")
+		}
+		users := make([]map[string]interface{}, 0)
+		for i := offset; i < total && i < offset+limit; i++ {
+			users = append(users, map[string]interface{}{
+				"user_name": fmt.Sprintf("user%d", i),
+				"groups":    []string{"group"},
+			})
+		}
+		_ = json.NewEncoder(w).Encode(users)
+	})
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/authorizations/", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[{"authorization_name":"auth","user_group":"group"},` +
+			`{"authorization_name":"other","user_group":"other"}]`))
+	})
+	provider := testProviderWithServer(t, mux)
+	ds := provider.DataSourcesMap["wallix-bastion_users"]
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"filter": "profile=user",
+		"expand": true,
+	})
+	if diags := ds.ReadContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if v := d.Get("users.#").(int); v != total {
+		t.Errorf("users.# = %d, want %d", v, total)
+	}
+	if v := d.Get("users.149.user_name").(string); v != "user149" {
+		t.Errorf("users.149.user_name = %q, want %q", v, "user149")
+	}
+	if v := d.Get("users.0.authorizations").([]interface{}); len(v) != 1 || v[0] != "auth" {
+		t.Errorf("users.0.authorizations = %v, want [auth]", v)
+	}
+}
+
+// TestDataSourceUsers_envelope checks the lists returned in an envelope with the total of objects.
+func TestDataSourceUsers_envelope(t *testing.T) {
+	requests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/users/", func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"items":[{"user_name":"user1"},{"user_name":"user2"}],"total":2}`))
+	})
+	provider := testProviderWithServer(t, mux)
+	ds := provider.DataSourcesMap["wallix-bastion_users"]
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{})
+	if diags := ds.ReadContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if v := d.Get("users.#").(int); v != 2 {
+		t.Errorf("users.# = %d, want 2", v)
+	}
+	if requests != 1 {
+		t.Errorf("%d requests, want 1", requests)
+	}
+}
+
+// TestDataSourceUsers_listPageSize checks the size of the pages requested with the list_page_size provider argument.
+func TestDataSourceUsers_listPageSize(t *testing.T) {
+	const total = 120
+	requests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/users/", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if limit != 50 {
+			t.Errorf("page requested with limit=%d, want 50", limit)
+		}
+		users := make([]map[string]interface{}, 0)
+		for i := offset; i < total && i < offset+limit; i++ {
+			users = append(users, map[string]interface{}{"user_name": fmt.Sprintf("user%d", i)})
+		}
+		_ = json.NewEncoder(w).Encode(users)
+	})
+	provider := testProviderWithServerConfig(t, mux, map[string]interface{}{
+		"list_page_size": 50,
+	})
+	ds := provider.DataSourcesMap["wallix-bastion_users"]
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{})
+	if diags := ds.ReadContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if v := d.Get("users.#").(int); v != total {
+		t.Errorf("users.# = %d, want %d", v, total)
+	}
+	// 3 pages of users and an empty page ending the list
+	if requests != 4 {
+		t.Errorf("%d requests, want 4", requests)
+	}
+}
+
+// TestDataSourceUsers_cappedPageSize checks all the users are read when the api returns pages
+// smaller than the list_page_size provider argument.
+func TestDataSourceUsers_cappedPageSize(t *testing.T) {
+	const total, maxLimit = 120, 30
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/users/", func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit = min(limit, maxLimit)
+		users := make([]map[string]interface{}, 0)
+		for i := offset; i < total && i < offset+limit; i++ {
+			users = append(users, map[string]interface{}{"user_name": fmt.Sprintf("user%d", i)})
+		}
+		_ = json.NewEncoder(w).Encode(users)
+	})
+	provider := testProviderWithServerConfig(t, mux, map[string]interface{}{
+		"list_page_size": 50,
+	})
+	ds := provider.DataSourcesMap["wallix-bastion_users"]
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{})
+	if diags := ds.ReadContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if v := d.Get("users.#").(int); v != total {
+		t.Errorf("users.# = %d, want %d", v, total)
+	}
+	if v := d.Get(fmt.Sprintf("users.%d.user_name", total-1)).(string); v != fmt.Sprintf("user%d", total-1) {
+		t.Errorf("last user = %q, want %q", v, fmt.Sprintf("user%d", total-1))
+	}
+}
+
+func testAccDataSourceUsersConfigCreate() string {
+	return `
+resource "wallix-bastion_usergroup" "testacc_dataUsers" {
+  group_name = "testacc_dataUsers"
+  timeframes = ["allthetime"]
+}
+resource "wallix-bastion_user" "testacc_dataUsers" {
+  user_name  = "testacc_dataUsers"
+  email      = "testacc-datausers@none.none"
+  profile    = "user"
+  user_auths = ["local_password"]
+  groups = [
+    wallix-bastion_usergroup.testacc_dataUsers.group_name,
+  ]
+}
+`
+}
+
+func testAccDataSourceUsersConfigData() string {
+	return `
+resource "wallix-bastion_usergroup" "testacc_dataUsers" {
+  group_name = "testacc_dataUsers"
+  timeframes = ["allthetime"]
+}
+resource "wallix-bastion_user" "testacc_dataUsers" {
+  user_name  = "testacc_dataUsers"
+  email      = "testacc-datausers@none.none"
+  profile    = "user"
+  user_auths = ["local_password"]
+  groups = [
+    wallix-bastion_usergroup.testacc_dataUsers.group_name,
+  ]
+}
+
+data "wallix-bastion_users" "testacc_dataUsers" {
+  filter = "user_name=testacc_dataUsers"
+  expand = true
+}
+`
+}
@@ -86,7 +86,7 @@ func readVersionOptions(
 	if err != nil {
 		return result, fmt.Errorf("preparing http request: %w", err)
 	}
-	resp, err := defaultHTTPClient.Do(req)
+	resp, err := c.getHTTPClient().Do(req)
 	if err != nil {
 		return result, fmt.Errorf("sending http request: %w", err)
 	}
@@ -2,9 +2,13 @@ package bastion
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
@@ -21,7 +25,7 @@ func defaultVersionsValid() []string {
 
 // Provider wallix-bastion for terraform.
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"ip": {
 				Type:        schema.TypeString,
@@ -53,13 +57,53 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("WALLIX_BASTION_API_VERSION", VersionWallixAPI38),
 			},
+			"strict_json": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("WALLIX_BASTION_STRICT_JSON", false),
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("WALLIX_BASTION_PROXY_URL", nil),
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+			},
+			"min_tls_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("WALLIX_BASTION_MIN_TLS_VERSION", "1.2"),
+				ValidateFunc: validation.StringInSlice([]string{"1.0", "1.1", "1.2", "1.3"}, false),
+			},
+			"list_page_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("WALLIX_BASTION_LIST_PAGE_SIZE", defaultListPageSize),
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"ignore_fields": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^wallix-bastion_[a-z0-9_]+\.[a-z0-9_]+$`),
+						"must be in the format <resource_type>.<attribute>"),
+				},
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"wallix-bastion_configoption":          dataSourceConfigoption(),
-			"wallix-bastion_domain":                dataSourceDomain(),
-			"wallix-bastion_local_password_policy": dataSourceLocalPasswordPolicy(),
-			"wallix-bastion_version":               dataSourceVersion(),
-			"wallix-bastion_authdomain_ad":         dataSourceAuthDomainAD(),
+			"wallix-bastion_application_localdomain":       dataSourceApplicationLocalDomain(),
+			"wallix-bastion_configoption":                  dataSourceConfigoption(),
+			"wallix-bastion_device_service":                dataSourceDeviceService(),
+			"wallix-bastion_devices":                       dataSourceDevices(),
+			"wallix-bastion_domain":                        dataSourceDomain(),
+			"wallix-bastion_externalauth_ldap":             dataSourceExternalAuthLdap(),
+			"wallix-bastion_local_password_policy":         dataSourceLocalPasswordPolicy(),
+			"wallix-bastion_local_password_policy_default": dataSourceLocalPasswordPolicyDefault(),
+			"wallix-bastion_notification":                  dataSourceNotification(),
+			"wallix-bastion_timeframe":                     dataSourceTimeframe(),
+			"wallix-bastion_users":                         dataSourceUsers(),
+			"wallix-bastion_version":                       dataSourceVersion(),
+			"wallix-bastion_authdomain_ad":                 dataSourceAuthDomainAD(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"wallix-bastion_application":                           resourceApplication(),
@@ -70,6 +114,7 @@ func Provider() *schema.Provider {
 			"wallix-bastion_authdomain_ldap":                       resourceAuthDomainLdap(),
 			"wallix-bastion_authdomain_mapping":                    resourceAuthDomainMapping(),
 			"wallix-bastion_authorization":                         resourceAuthorization(),
+			"wallix-bastion_authorization_approver":                resourceAuthorizationApprover(),
 			"wallix-bastion_checkout_policy":                       resourceCheckoutPolicy(),
 			"wallix-bastion_cluster":                               resourceCluster(),
 			"wallix-bastion_config_x509":                           resourceConfigX509(),
@@ -82,6 +127,7 @@ func Provider() *schema.Provider {
 			"wallix-bastion_device_service":                        resourceDeviceService(),
 			"wallix-bastion_domain":                                resourceDomain(),
 			"wallix-bastion_domain_account":                        resourceDomainAccount(),
+			"wallix-bastion_domain_accounts_auto_change":           resourceDomainAccountsAutoChange(),
 			"wallix-bastion_domain_account_credential":             resourceDomainAccountCredential(),
 			"wallix-bastion_externalauth_kerberos":                 resourceExternalAuthKerberos(),
 			"wallix-bastion_externalauth_ldap":                     resourceExternalAuthLdap(),
@@ -95,15 +141,34 @@ func Provider() *schema.Provider {
 			"wallix-bastion_user":                                  resourceUser(),
 			"wallix-bastion_usergroup":                             resourceUserGroup(),
 		},
-		ConfigureContextFunc: configureProvider,
 	}
+	for resourceType, res := range p.ResourcesMap {
+		res.ReadContext = readIgnoringFields(resourceType, res.ReadContext)
+	}
+	p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		return configureProvider(ctx, d, p.ResourcesMap)
+	}
+
+	return p
 }
 
 func configureProvider(
-	_ context.Context, d *schema.ResourceData,
+	_ context.Context, d *schema.ResourceData, resources map[string]*schema.Resource,
 ) (
 	interface{}, diag.Diagnostics,
 ) {
+	ignoreFields := make([]string, 0)
+	for _, v := range d.Get("ignore_fields").(*schema.Set).List() {
+		resourceType, attribute, _ := strings.Cut(v.(string), ".")
+		res, ok := resources[resourceType]
+		if !ok {
+			return nil, diag.FromErr(fmt.Errorf("ignore_fields: unknown resource type %s", resourceType))
+		}
+		if _, ok := res.Schema[attribute]; !ok {
+			return nil, diag.FromErr(fmt.Errorf("ignore_fields: %s doesn't have a %s argument", resourceType, attribute))
+		}
+		ignoreFields = append(ignoreFields, v.(string))
+	}
 	config := Config{
 		bastionAPIVersion: d.Get("api_version").(string),
 		bastionIP:         d.Get("ip").(string),
@@ -111,7 +176,50 @@ func configureProvider(
 		bastionToken:      d.Get("token").(string),
 		bastionUser:       d.Get("user").(string),
 		bastionPwd:        d.Get("password").(string),
+		strictJSON:        d.Get("strict_json").(bool),
+		proxyURL:          d.Get("proxy_url").(string),
+		minTLSVersion:     d.Get("min_tls_version").(string),
+		listPageSize:      d.Get("list_page_size").(int),
+		ignoreFields:      ignoreFields,
 	}
 
 	return config.Client()
 }
+
+// readIgnoringFields wraps the read of a resource to keep in the state the values of the attributes
+// listed in the ignore_fields argument of the provider, so changes made outside of Terraform
+// on these attributes don't produce a diff.
+func readIgnoringFields(resourceType string, read schema.ReadContextFunc) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		c, ok := m.(*Client)
+		// no state to keep after a create, let the read fill it
+		if !ok || len(c.ignoreFields) == 0 || d.IsNewResource() {
+			return read(ctx, d, m)
+		}
+		rawState := d.GetRawState()
+		kept := make(map[string]interface{})
+		for _, v := range c.ignoreFields {
+			attribute, found := strings.CutPrefix(v, resourceType+".")
+			if !found {
+				continue
+			}
+			// no value in the state after an import, let the read fill it
+			if rawState.IsKnown() && !rawState.IsNull() && rawState.GetAttr(attribute).IsNull() {
+				continue
+			}
+			// the value of the state is kept even when it's empty (or false)
+			kept[attribute] = d.Get(attribute)
+		}
+		diags := read(ctx, d, m)
+		if diags.HasError() || d.Id() == "" {
+			return diags
+		}
+		for attribute, value := range kept {
+			if tfErr := d.Set(attribute, value); tfErr != nil {
+				panic(tfErr)
+			}
+		}
+
+		return diags
+	}
+}
@@ -1,12 +1,22 @@
 package bastion_test
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -28,6 +38,477 @@ func TestProvider_impl(_ *testing.T) {
 	var _ *schema.Provider = bastion.Provider()
 }
 
+func TestProvider_apiVersion(t *testing.T) {
+	for _, version := range []string{"", "v2.0"} {
+		provider := bastion.Provider()
+		diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+			"ip":          "127.0.0.1",
+			"user":        "admin",
+			"api_version": version,
+		}))
+		if !diags.HasError() {
+			t.Fatalf("configure with api_version %q: expected an error", version)
+		}
+		if len(diags) != 1 {
+			t.Fatalf("configure with api_version %q: expected one diagnostic, got %d", version, len(diags))
+		}
+	}
+}
+
+func TestProvider_proxyURL(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodConnect {
+			proxied = r.Host
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	t.Cleanup(proxy.Close)
+	provider := bastion.Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"ip":          "bastion.invalid",
+		"user":        "admin",
+		"token":       "token",
+		"api_version": bastion.VersionWallixAPI312,
+		"proxy_url":   proxy.URL,
+	}))
+	if diags.HasError() {
+		t.Fatalf("configuring provider: %v", diags)
+	}
+	ds := provider.DataSourcesMap["wallix-bastion_version"]
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{})
+	if diags := ds.ReadContext(context.Background(), d, provider.Meta()); !diags.HasError() {
+		t.Fatal("read through a refusing proxy: expected an error")
+	}
+	if proxied != "bastion.invalid:443" {
+		t.Errorf("proxy received CONNECT to %q, want %q", proxied, "bastion.invalid:443")
+	}
+}
+
+func TestProvider_ignoreFields(t *testing.T) {
+	provider := testProviderWithServerConfig(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"dev","device_name":"dev","host":"host.none","alias":"changed",` +
+			`"description":"changed outside of terraform","local_domains":[],"services":[]}`))
+	}), map[string]interface{}{
+		"ignore_fields": []interface{}{"wallix-bastion_device.description"},
+	})
+	res := provider.ResourcesMap["wallix-bastion_device"]
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"device_name": "dev",
+		"host":        "host.none",
+		"alias":       "alias",
+		"description": "managed by terraform",
+	})
+	d.SetId("dev")
+	if diags := res.ReadContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if v := d.Get("description").(string); v != "managed by terraform" {
+		t.Errorf("description = %q, want the value kept in the state", v)
+	}
+	if v := d.Get("alias").(string); v != "changed" {
+		t.Errorf("alias = %q, want the value read from the api", v)
+	}
+
+	// an empty value in the state is kept too
+	d = schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"device_name": "dev",
+		"host":        "host.none",
+		"alias":       "alias",
+		"description": "",
+	})
+	d.SetId("dev")
+	if diags := res.ReadContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if v := d.Get("description").(string); v != "" {
+		t.Errorf("description = %q, want the empty value kept in the state", v)
+	}
+
+	for _, field := range []string{"wallix-bastion_unknown.description", "wallix-bastion_device.unknown"} {
+		diags := bastion.Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+			"ip":            "127.0.0.1",
+			"user":          "admin",
+			"api_version":   bastion.VersionWallixAPI312,
+			"ignore_fields": []interface{}{field},
+		}))
+		if !diags.HasError() {
+			t.Errorf("configure with ignore_fields %q: expected an error", field)
+		}
+	}
+}
+
+func TestProvider_strictJSON(t *testing.T) {
+	for _, tc := range []struct {
+		strictJSON bool
+		warnings   int
+	}{
+		{strictJSON: false, warnings: 0},
+		{strictJSON: true, warnings: 2}, // one for the search in the list, one for the read of the object
+	} {
+		posted := false
+		cluster := `{"id":"c1","cluster_name":"cluster","description":"desc","accounts":["acc@dev:SSH"],` +
+			`"account_mappings":[],"interactive_logins":[],"unknown_field":"new in the api"}`
+		provider := testProviderWithServerConfig(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodPost:
+				posted = true
+				w.WriteHeader(http.StatusNoContent)
+			case r.URL.Path == "/api/"+bastion.VersionWallixAPI312+"/clusters/c1":
+				_, _ = w.Write([]byte(cluster))
+			case posted:
+				_, _ = w.Write([]byte("[" + cluster + "]"))
+			default:
+				_, _ = w.Write([]byte("[]"))
+			}
+		}), map[string]interface{}{
+			"strict_json": tc.strictJSON,
+		})
+		res := provider.ResourcesMap["wallix-bastion_cluster"]
+		d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+			"cluster_name": "cluster",
+			"description":  "desc",
+			"accounts":     []interface{}{"acc@dev:SSH"},
+		})
+		var logs bytes.Buffer
+		ctx := tflogtest.RootLogger(context.Background(), &logs)
+		if diags := res.CreateContext(ctx, d, provider.Meta()); diags.HasError() {
+			t.Fatalf("strict_json %t: create: %v", tc.strictJSON, diags)
+		}
+		if d.Id() != "c1" || d.Get("description").(string) != "desc" {
+			t.Errorf("strict_json %t: id = %q, description = %q, want the known fields decoded",
+				tc.strictJSON, d.Id(), d.Get("description"))
+		}
+		entries, err := tflogtest.MultilineJSONDecode(&logs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		warnings := 0
+		for _, entry := range entries {
+			if entry["@level"] == "warn" {
+				if !strings.Contains(entry["error"].(string), "unknown_field") {
+					t.Errorf("strict_json %t: warning %v doesn't name the unknown field", tc.strictJSON, entry)
+				}
+				warnings++
+			}
+		}
+		if warnings != tc.warnings {
+			t.Errorf("strict_json %t: got %d warnings, want %d", tc.strictJSON, warnings, tc.warnings)
+		}
+	}
+}
+
+func TestProvider_minTLSVersion(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[{"id":"1","notification_name":"audit"}]`))
+	}))
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		minTLSVersion string
+		wantErr       bool
+	}{
+		{"1.2", false},
+		{"1.3", true},
+	} {
+		provider := bastion.Provider()
+		diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+			"ip":              host,
+			"port":            port,
+			"user":            "admin",
+			"token":           "token",
+			"api_version":     bastion.VersionWallixAPI312,
+			"min_tls_version": tc.minTLSVersion,
+		}))
+		if diags.HasError() {
+			t.Fatalf("configuring provider: %v", diags)
+		}
+		ds := provider.DataSourcesMap["wallix-bastion_notification"]
+		d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+			"notification_name": "audit",
+		})
+		diags = ds.ReadContext(context.Background(), d, provider.Meta())
+		if diags.HasError() != tc.wantErr {
+			t.Errorf("min_tls_version = %s with a TLS 1.2 server: diags = %v, want error %t",
+				tc.minTLSVersion, diags, tc.wantErr)
+		}
+	}
+}
+
+// TestProvider_rateLimit checks the requests wait for the reset when the api advertises no remaining request.
+func TestProvider_rateLimit(t *testing.T) {
+	var requests []time.Time
+	provider := testProviderWithServer(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests = append(requests, time.Now())
+		if len(requests) == 1 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "1")
+		}
+		_, _ = w.Write([]byte(`[{"id":"1","notification_name":"audit"}]`))
+	}))
+	ds := provider.DataSourcesMap["wallix-bastion_notification"]
+	for range 2 {
+		d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+			"notification_name": "audit",
+		})
+		if diags := ds.ReadContext(context.Background(), d, provider.Meta()); diags.HasError() {
+			t.Fatalf("read: %v", diags)
+		}
+	}
+	if len(requests) != 2 {
+		t.Fatalf("%d requests, want 2", len(requests))
+	}
+	if wait := requests[1].Sub(requests[0]); wait < 900*time.Millisecond {
+		t.Errorf("second request sent %s after the first one, want to wait for the reset", wait)
+	}
+}
+
+// TestProvider_conflictRetry checks an update refused by a concurrent modification is sent again
+// after a merge with the object read again, and a conflict of the api is returned without a retry.
+func TestProvider_conflictRetry(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		refused []int
+		current string
+		puts    int
+		err     string
+	}{
+		{"merged", []int{http.StatusPreconditionFailed}, `"description":"old","is_overtimable":true`, 2, ""},
+		{
+			"refused again", []int{http.StatusPreconditionFailed, http.StatusPreconditionFailed},
+			`"description":"old","is_overtimable":true`, 2, "modified concurrently",
+		},
+		{
+			"same field", []int{http.StatusPreconditionFailed},
+			`"description":"other","is_overtimable":false`, 1, "description changed by another client",
+		},
+		{"conflict", []int{http.StatusConflict}, `"description":"old","is_overtimable":false`, 1, "doesn't return OK"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var puts []map[string]interface{}
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/timeframes/workhours",
+				func(w http.ResponseWriter, r *http.Request) {
+					if r.Method == http.MethodPut {
+						var body map[string]interface{}
+						if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+							t.Fatal(err)
+						}
+						puts = append(puts, body)
+						if len(puts) <= len(tc.refused) {
+							w.WriteHeader(tc.refused[len(puts)-1])
+							_, _ = w.Write([]byte(`{"error":"refused"}`))
+
+							return
+						}
+						w.WriteHeader(http.StatusNoContent)
+
+						return
+					}
+					_, _ = w.Write([]byte(`{"timeframe_name":"workhours",` + tc.current + `,"periods":[]}`))
+				})
+			provider := testProviderWithServer(t, mux)
+			res := provider.ResourcesMap["wallix-bastion_timeframe"]
+			state := &terraform.InstanceState{
+				ID: "workhours",
+				Attributes: map[string]string{
+					"id":             "workhours",
+					"timeframe_name": "workhours",
+					"description":    "old",
+					"is_overtimable": "false",
+				},
+			}
+			diff := &terraform.InstanceDiff{Attributes: map[string]*terraform.ResourceAttrDiff{
+				"description": {Old: "old", New: "new"},
+			}}
+			_, diags := res.Apply(context.Background(), state, diff, provider.Meta())
+			switch {
+			case tc.err == "" && diags.HasError():
+				t.Fatalf("update: %v", diags)
+			case tc.err != "" && (!diags.HasError() || !strings.Contains(diags[0].Summary, tc.err)):
+				t.Errorf("expected an error with %q, got %v", tc.err, diags)
+			}
+			if len(puts) != tc.puts {
+				t.Fatalf("%d PUT sent, want %d", len(puts), tc.puts)
+			}
+			if tc.puts == 2 && (puts[1]["description"] != "new" || puts[1]["is_overtimable"] != true) {
+				t.Errorf("PUT sent again = %v, want the new description and is_overtimable of the other client",
+					puts[1])
+			}
+		})
+	}
+}
+
+// TestProvider_allowDestroy checks the resources with allow_destroy set to false aren't deleted on the Bastion.
+func TestProvider_allowDestroy(t *testing.T) {
+	deleted := make(map[string]bool)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleted[r.URL.Path] = true
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	provider := testProviderWithServer(t, mux)
+	for resourceType, uri := range map[string]string{
+		"wallix-bastion_authorization": "/authorizations/",
+		"wallix-bastion_device":        "/devices/",
+		"wallix-bastion_domain":        "/domains/",
+		"wallix-bastion_usergroup":     "/usergroups/",
+	} {
+		res := provider.ResourcesMap[resourceType]
+		path := "/api/" + bastion.VersionWallixAPI312 + uri + "object-id"
+		d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+			"allow_destroy": false,
+		})
+		d.SetId("object-id")
+		diags := res.DeleteContext(context.Background(), d, provider.Meta())
+		if !diags.HasError() || !strings.Contains(diags[0].Summary, "protected against deletion") {
+			t.Errorf("%s: delete with allow_destroy = false: expected an error, got %v", resourceType, diags)
+		}
+		if deleted[path] {
+			t.Errorf("%s: DELETE sent with allow_destroy = false", resourceType)
+		}
+		d = schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{})
+		d.SetId("object-id")
+		if diags := res.DeleteContext(context.Background(), d, provider.Meta()); diags.HasError() {
+			t.Errorf("%s: delete with allow_destroy by default: %v", resourceType, diags)
+		}
+		if !deleted[path] {
+			t.Errorf("%s: DELETE not sent with allow_destroy by default", resourceType)
+		}
+	}
+}
+
+// TestProvider_deleteNotFound checks a resource already deleted outside of terraform isn't an error on delete.
+func TestProvider_deleteNotFound(t *testing.T) {
+	deleted := make(map[string]bool)
+	provider := testProviderWithServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleted[r.URL.Path] = true
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"Not Found","description":"object not found"}`))
+	}))
+	for _, tc := range []struct {
+		resourceType string
+		uri          string
+		state        map[string]interface{}
+	}{
+		{"wallix-bastion_application", "/applications/object-id", map[string]interface{}{
+			"application_name": "app",
+		}},
+		{"wallix-bastion_externalauth_ldap", "/externalauths/object-id", map[string]interface{}{
+			"authentication_name": "ldap",
+		}},
+		{"wallix-bastion_device_localdomain_account_credential",
+			"/devices/dev/localdomains/dom/accounts/acc/credentials/object-id", map[string]interface{}{
+				"device_id":  "dev",
+				"domain_id":  "dom",
+				"account_id": "acc",
+			}},
+	} {
+		res := provider.ResourcesMap[tc.resourceType]
+		d := schema.TestResourceDataRaw(t, res.Schema, tc.state)
+		d.SetId("object-id")
+		if diags := res.DeleteContext(context.Background(), d, provider.Meta()); len(diags) > 0 {
+			t.Errorf("%s: delete of an object not found: unexpected diagnostics %v", tc.resourceType, diags)
+		}
+		if !deleted["/api/"+bastion.VersionWallixAPI312+tc.uri] {
+			t.Errorf("%s: DELETE not sent on %s", tc.resourceType, tc.uri)
+		}
+	}
+}
+
+// TestProvider_updateNoOp checks an update is only sent when the json to put is different
+// from the json of the last read.
+func TestProvider_updateNoOp(t *testing.T) {
+	puts := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			puts++
+			w.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+	provider := testProviderWithServer(t, mux)
+	for _, tc := range []struct {
+		resourceType string
+		attribute    string
+		state        map[string]string
+	}{
+		{"wallix-bastion_application", "description", map[string]string{
+			"target": "cluster", "paths.#": "1", "paths.0.target": "Interactive@dev:svc", "paths.0.program": "prog",
+		}},
+		// the local domains read aren't in the configuration
+		{"wallix-bastion_application", "description", map[string]string{
+			"target": "cluster", "paths.#": "1", "paths.0.target": "Interactive@dev:svc", "paths.0.program": "prog",
+			"local_domains.#": "1", "local_domains.0.id": "local-id", "local_domains.0.domain_name": "local",
+		}},
+		{"wallix-bastion_application_localdomain", "description", nil},
+		{"wallix-bastion_application_localdomain_account", "description", nil},
+		{"wallix-bastion_authdomain_ad", "description", nil},
+		{"wallix-bastion_authdomain_azuread", "description", nil},
+		{"wallix-bastion_authdomain_ldap", "description", nil},
+		{"wallix-bastion_authdomain_mapping", "user_group", nil},
+		{"wallix-bastion_authorization", "description", nil},
+		{"wallix-bastion_checkout_policy", "description", nil},
+		{"wallix-bastion_cluster", "description", nil},
+		{"wallix-bastion_connection_message", "message", nil},
+		{"wallix-bastion_connection_policy", "description", nil},
+		{"wallix-bastion_device", "description", nil},
+		{"wallix-bastion_device_localdomain", "description", nil},
+		{"wallix-bastion_device_localdomain_account", "description", nil},
+		{"wallix-bastion_device_service", "connection_policy", nil},
+		{"wallix-bastion_domain", "description", nil},
+		// the rotation isn't in the json but sends the generate value again
+		{"wallix-bastion_domain", "ca_key_rotation", map[string]string{"ca_private_key": "generate:ED25519"}},
+		{"wallix-bastion_domain_account", "description", nil},
+		{"wallix-bastion_externalauth_kerberos", "description", nil},
+		{"wallix-bastion_externalauth_ldap", "description", map[string]string{"login": "admin", "password": "secret"}},
+		{"wallix-bastion_externalauth_radius", "description", nil},
+		{"wallix-bastion_externalauth_saml", "description", nil},
+		{"wallix-bastion_externalauth_tacacs", "description", nil},
+		{"wallix-bastion_profile", "description", nil},
+		{"wallix-bastion_targetgroup", "description", nil},
+		{"wallix-bastion_timeframe", "description", nil},
+		{"wallix-bastion_user", "email", nil},
+		{"wallix-bastion_usergroup", "description", nil},
+	} {
+		resourceType, attribute := tc.resourceType, tc.attribute
+		res := provider.ResourcesMap[resourceType]
+		state := &terraform.InstanceState{
+			ID:         "object-id",
+			Attributes: map[string]string{"id": "object-id", attribute: "old"},
+			RawConfig:  testEmptyRawConfig(res),
+		}
+		for k, v := range tc.state {
+			state.Attributes[k] = v
+		}
+		for newValue, wantPuts := range map[string]int{"old": 0, "new": 1} {
+			puts = 0
+			diff := &terraform.InstanceDiff{Attributes: map[string]*terraform.ResourceAttrDiff{
+				attribute: {Old: "old", New: newValue},
+			}}
+			if _, diags := res.Apply(context.Background(), state, diff, provider.Meta()); diags.HasError() {
+				t.Errorf("%s: update of %s from old to %s: %v", resourceType, attribute, newValue, diags)
+			}
+			if puts != wantPuts {
+				t.Errorf("%s: %d PUT sent with %s from old to %s, want %d",
+					resourceType, puts, attribute, newValue, wantPuts)
+			}
+		}
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	t.Helper()
 	if os.Getenv("WALLIX_BASTION_HOST") == "" {
@@ -44,3 +525,49 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// testEmptyRawConfig returns a configuration of res without any argument set,
+// like the one sent by Terraform, for the code checking what is in the configuration.
+func testEmptyRawConfig(res *schema.Resource) cty.Value {
+	attributes := make(map[string]cty.Value)
+	for k, v := range res.CoreConfigSchema().ImpliedType().AttributeTypes() {
+		attributes[k] = cty.NullVal(v)
+	}
+
+	return cty.ObjectVal(attributes)
+}
+
+// testProviderWithServer configures a provider against a fake bastion api served by handler.
+func testProviderWithServer(t *testing.T, handler http.Handler) *schema.Provider {
+	t.Helper()
+
+	return testProviderWithServerConfig(t, handler, nil)
+}
+
+// testProviderWithServerConfig is like testProviderWithServer with additional arguments for the provider.
+func testProviderWithServerConfig(t *testing.T, handler http.Handler, config map[string]interface{}) *schema.Provider {
+	t.Helper()
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := map[string]interface{}{
+		"ip":          host,
+		"port":        port,
+		"user":        "admin",
+		"token":       "token",
+		"api_version": bastion.VersionWallixAPI312,
+	}
+	for k, v := range config {
+		raw[k] = v
+	}
+	provider := bastion.Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	if diags.HasError() {
+		t.Fatalf("configuring provider: %v", diags)
+	}
+
+	return provider
+}
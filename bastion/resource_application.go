@@ -6,7 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"slices"
+	"strings"
+	"unicode"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -45,6 +48,7 @@ func resourceApplication() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceApplicationImport,
 		},
+		CustomizeDiff: resourceApplicationCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"application_name": {
 				Type:     schema.TypeString,
@@ -86,8 +90,13 @@ func resourceApplication() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"parameters_decoded": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 			"paths": {
-				Type:     schema.TypeSet,
+				Type:     schema.TypeList,
 				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -96,13 +105,15 @@ func resourceApplication() *schema.Resource {
 							Required: true,
 						},
 						"program": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:             schema.TypeString,
+							Required:         true,
+							DiffSuppressFunc: suppressTrailingSpaceDiff,
 						},
 						"working_dir": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "",
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "",
+							DiffSuppressFunc: suppressTrailingSpaceDiff,
 						},
 					},
 				},
@@ -111,8 +122,17 @@ func resourceApplication() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"target_host": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"target_service": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"local_domains": {
 				Type:     schema.TypeList,
+				Optional: true,
 				Computed: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -122,35 +142,43 @@ func resourceApplication() *schema.Resource {
 						},
 						"admin_account": {
 							Type:     schema.TypeString,
+							Optional: true,
 							Computed: true,
 						},
 						"domain_name": {
 							Type:     schema.TypeString,
-							Computed: true,
+							Required: true,
 						},
 						"description": {
 							Type:     schema.TypeString,
-							Computed: true,
+							Optional: true,
 						},
 						"enable_password_change": {
 							Type:     schema.TypeBool,
-							Computed: true,
+							Optional: true,
 						},
 						"password_change_policy": {
 							Type:     schema.TypeString,
-							Computed: true,
+							Optional: true,
 						},
 						"password_change_plugin": {
 							Type:     schema.TypeString,
-							Computed: true,
+							Optional: true,
 						},
 						"password_change_plugin_parameters": {
-							Type:     schema.TypeString,
-							Computed: true,
+							Type:             schema.TypeString,
+							Optional:         true,
+							Sensitive:        true,
+							ValidateFunc:     validation.StringIsJSON,
+							DiffSuppressFunc: suppressEquivalentJSONDiff,
 						},
 					},
 				},
 			},
+			"href": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -160,7 +188,59 @@ func resourceApplicationVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_application not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_application", version)
+}
+
+// resourceApplicationCustomizeDiff plans parameters_decoded from parameters and target_host and target_service
+// from target, and rejects paths with the same target,
+// the Bastion keeps only one of them and the next plan shows a diff.
+func resourceApplicationCustomizeDiff(
+	_ context.Context, d *schema.ResourceDiff, _ interface{},
+) error {
+	if d.HasChange("parameters") {
+		if !d.NewValueKnown("parameters") {
+			if err := d.SetNewComputed("parameters_decoded"); err != nil {
+				return fmt.Errorf("setting parameters_decoded: %w", err)
+			}
+		} else if err := d.SetNew("parameters_decoded",
+			decodeApplicationParameters(d.Get("parameters").(string))); err != nil {
+			return fmt.Errorf("setting parameters_decoded: %w", err)
+		}
+	}
+	if d.HasChange("target") {
+		if !d.NewValueKnown("target") {
+			for _, k := range []string{"target_host", "target_service"} {
+				if err := d.SetNewComputed(k); err != nil {
+					return fmt.Errorf("setting %s: %w", k, err)
+				}
+			}
+		} else {
+			targetHost, targetService := splitApplicationTarget(d.Get("target").(string))
+			if err := d.SetNew("target_host", targetHost); err != nil {
+				return fmt.Errorf("setting target_host: %w", err)
+			}
+			if err := d.SetNew("target_service", targetService); err != nil {
+				return fmt.Errorf("setting target_service: %w", err)
+			}
+		}
+	}
+	if !d.NewValueKnown("paths") {
+		return nil
+	}
+	var errs []error
+	targets := make(map[string]int)
+	for _, v := range d.Get("paths").([]interface{}) {
+		target := v.(map[string]interface{})["target"].(string)
+		if target == "" {
+			continue
+		}
+		targets[target]++
+		if targets[target] == 2 {
+			errs = append(errs, fmt.Errorf("paths: target %s is configured more than once", target))
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
 func resourceApplicationCreate(
@@ -190,7 +270,7 @@ func resourceApplicationCreate(
 	}
 	d.SetId(id)
 
-	return resourceApplicationRead(ctx, d, m)
+	return readApplicationAfterWrite(ctx, d, m)
 }
 
 func resourceApplicationRead(
@@ -208,6 +288,9 @@ func resourceApplicationRead(
 		d.SetId("")
 	} else {
 		fillApplication(d, cfg)
+		if tfErr := d.Set("href", c.apiURL("/applications/"+url.PathEscape(d.Id()))); tfErr != nil {
+			panic(tfErr)
+		}
 	}
 
 	return nil
@@ -226,7 +309,53 @@ func resourceApplicationUpdate(
 	}
 	d.Partial(false)
 
-	return resourceApplicationRead(ctx, d, m)
+	return readApplicationAfterWrite(ctx, d, m)
+}
+
+// readApplicationAfterWrite reads the application after a create or an update,
+// checks the Bastion hasn't ignored a new name (the application keeps its id when renamed)
+// and warns about the configured paths not returned by the Bastion,
+// which drops an invalid path without an error and a diff is then shown on each plan.
+func readApplicationAfterWrite(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	name := d.Get("application_name").(string)
+	configured := d.Get("paths").([]interface{})
+	diags := readAfterWriteDiags(resourceApplicationRead(ctx, d, m))
+	if len(diags) > 0 || d.Id() == "" {
+		return diags
+	}
+	if v := d.Get("application_name").(string); v != name {
+		return diag.FromErr(fmt.Errorf("application %s not renamed to %s by the Bastion, "+
+			"replace the resource to change its name", v, name))
+	}
+	returned := make(map[string]bool)
+	for _, v := range d.Get("paths").([]interface{}) {
+		returned[applicationPathKey(v.(map[string]interface{}))] = true
+	}
+	for _, v := range configured {
+		path := v.(map[string]interface{})
+		if returned[applicationPathKey(path)] {
+			continue
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary: fmt.Sprintf("path with target %s and program %s not kept by the Bastion",
+				path["target"].(string), path["program"].(string)),
+			Detail: fmt.Sprintf("application %s written but the Bastion doesn't return this path, "+
+				"check the target of the path is valid", d.Get("application_name").(string)),
+		})
+	}
+
+	return diags
+}
+
+// applicationPathKey returns a key to compare a path of the configuration with the paths returned by the Bastion,
+// trailing spaces of program and working_dir are ignored like in the diff.
+func applicationPathKey(path map[string]interface{}) string {
+	return path["target"].(string) + "\x00" +
+		strings.TrimRightFunc(path["program"].(string), unicode.IsSpace) + "\x00" +
+		strings.TrimRightFunc(path["working_dir"].(string), unicode.IsSpace)
 }
 
 func resourceApplicationDelete(
@@ -278,20 +407,20 @@ func searchResourceApplication(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/applications/?q=application_name="+applicationName, http.MethodGet, nil)
+	var results []jsonApplication
+	body, code, err := c.newRequestJSON(ctx,
+		"/applications/?q=application_name="+applicationName, http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
+	// only an api error is an error, an empty list (or an empty body) means the application doesn't exist
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonApplication
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
-	if len(results) == 1 {
-		return results[0].ID, true, nil
+	for _, v := range results {
+		if v.ApplicationName == applicationName {
+			return v.ID, true, nil
+		}
 	}
 
 	return "", false, nil
@@ -324,7 +453,15 @@ func updateApplication(
 	if err != nil {
 		return err
 	}
-	body, code, err := c.newRequest(ctx, "/applications/"+d.Id()+"?force=true", http.MethodPut, jsonData)
+	var priorJSONData interface{}
+	if v, err := prepareApplicationJSON(priorResourceData(resourceApplication(), d), false, apiVersion); err == nil {
+		// the prior data has no configuration, the local domains read aren't sent when not configured
+		if jsonData.LocalDomains == nil {
+			v.LocalDomains = nil
+		}
+		priorJSONData = v
+	}
+	body, code, err := c.putIfChanged(ctx, "/applications/"+d.Id()+"?force=true", jsonData, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -343,6 +480,13 @@ func deleteApplication(
 	if err != nil {
 		return err
 	}
+	// already deleted outside of terraform
+	if code == http.StatusNotFound {
+		return nil
+	}
+	if code == http.StatusConflict {
+		return inUseDeleteError("application", d.Get("application_name").(string), body, nil)
+	}
 	if code != http.StatusOK && code != http.StatusNoContent {
 		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
 	}
@@ -383,7 +527,7 @@ func prepareApplicationJSON(
 		}
 		jsonData.Target = &target
 
-		listPaths := d.Get("paths").(*schema.Set).List()
+		listPaths := d.Get("paths").([]interface{})
 		if len(listPaths) == 0 {
 			return jsonData, errors.New("paths must be specified when category = standard")
 		}
@@ -399,9 +543,11 @@ func prepareApplicationJSON(
 		jsonData.Paths = &jsonDataPaths
 
 		listGlobalDomains := d.Get("global_domains").(*schema.Set).List()
-		jsonDataGlobalDomains := make([]string, len(listGlobalDomains))
-		for i, v := range listGlobalDomains {
-			jsonDataGlobalDomains[i] = v.(string)
+		jsonDataGlobalDomains := make([]string, 0, len(listGlobalDomains))
+		for _, v := range listGlobalDomains {
+			if v.(string) != "" {
+				jsonDataGlobalDomains = append(jsonDataGlobalDomains, v.(string))
+			}
 		}
 		jsonData.GlobalDomains = &jsonDataGlobalDomains
 
@@ -412,11 +558,11 @@ func prepareApplicationJSON(
 		if d.Get("target").(string) != "" {
 			return jsonData, errors.New("target cannot be configured when category = jumphost")
 		}
-		if len(d.Get("paths").(*schema.Set).List()) > 0 {
+		if len(d.Get("paths").([]interface{})) > 0 {
 			return jsonData, errors.New("paths cannot be configured when category = jumphost")
 		}
 		if len(d.Get("global_domains").(*schema.Set).List()) > 0 {
-			return jsonData, errors.New("paths cannot be configured when category = jumphost")
+			return jsonData, errors.New("global_domains cannot be configured when category = jumphost")
 		}
 
 		applicationURL := d.Get("application_url").(string)
@@ -434,10 +580,76 @@ func prepareApplicationJSON(
 		browserVersion := d.Get("browser_version").(string)
 		jsonData.BrowserVersion = &browserVersion
 	}
+	if applicationLocalDomainsConfigured(d) {
+		jsonData.LocalDomains = prepareApplicationLocalDomainsJSON(d, newResource)
+	}
 
 	return jsonData, nil
 }
 
+// applicationLocalDomainsConfigured returns true when the local_domains block is in the configuration,
+// without it the local domains are only read (and can be managed with wallix-bastion_application_localdomain).
+func applicationLocalDomainsConfigured(d *schema.ResourceData) bool {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsKnown() && !rawConfig.IsNull() {
+		localDomains := rawConfig.GetAttr("local_domains")
+
+		return localDomains.IsKnown() && !localDomains.IsNull() && localDomains.LengthInt() > 0
+	}
+
+	return len(d.Get("local_domains").([]interface{})) > 0
+}
+
+// prepareApplicationLocalDomainsJSON returns the local domains of the configuration
+// with the id of those already on the application, so the Bastion keeps them on update.
+func prepareApplicationLocalDomainsJSON(
+	d *schema.ResourceData, newResource bool,
+) *[]jsonApplicationLocalDomain {
+	ids := make(map[string]string)
+	if !newResource {
+		oldLocalDomains, _ := d.GetChange("local_domains")
+		for _, v := range oldLocalDomains.([]interface{}) {
+			if localDomain, ok := v.(map[string]interface{}); ok {
+				ids[localDomain["domain_name"].(string)] = localDomain["id"].(string)
+			}
+		}
+	}
+	listLocalDomains := d.Get("local_domains").([]interface{})
+	jsonDataLocalDomains := make([]jsonApplicationLocalDomain, 0, len(listLocalDomains))
+	for _, v := range listLocalDomains {
+		localDomain, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		jsonDataLocalDomain := jsonApplicationLocalDomain{
+			ID:          ids[localDomain["domain_name"].(string)],
+			DomainName:  localDomain["domain_name"].(string),
+			Description: localDomain["description"].(string),
+		}
+		if localDomain["enable_password_change"].(bool) {
+			// the admin account is an account of the local domain, it can't exist on create
+			if !newResource {
+				adminAccount := localDomain["admin_account"].(string)
+				jsonDataLocalDomain.AdminAccount = &adminAccount
+			}
+			jsonDataLocalDomain.EnablePasswordChange = true
+			jsonDataLocalDomain.PasswordChangePolicy = localDomain["password_change_policy"].(string)
+			jsonDataLocalDomain.PasswordChangePlugin = localDomain["password_change_plugin"].(string)
+			var passChgPlug map[string]interface{}
+			if v := localDomain["password_change_plugin_parameters"].(string); v != "" {
+				_ = json.Unmarshal([]byte(v), &passChgPlug)
+			}
+			if passChgPlug == nil {
+				passChgPlug = make(map[string]interface{})
+			}
+			jsonDataLocalDomain.PasswordChangePluginParameters = &passChgPlug
+		}
+		jsonDataLocalDomains = append(jsonDataLocalDomains, jsonDataLocalDomain)
+	}
+
+	return &jsonDataLocalDomains
+}
+
 func readApplicationOptions(
 	ctx context.Context, applicationID string, m interface{},
 ) (
@@ -455,9 +667,8 @@ func readApplicationOptions(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
-	if err != nil {
-		return result, fmt.Errorf("unmarshaling json: %w", err)
+	if err := c.unmarshalBody(ctx, body, &result); err != nil {
+		return result, err
 	}
 
 	return result, nil
@@ -507,12 +718,25 @@ func fillApplication(d *schema.ResourceData, jsonData jsonApplication) { //nolin
 	if tfErr := d.Set("description", jsonData.Description); tfErr != nil {
 		panic(tfErr)
 	}
-	if tfErr := d.Set("global_domains", jsonData.GlobalDomains); tfErr != nil {
+	globalDomains := make([]string, 0)
+	if jsonData.GlobalDomains != nil {
+		for _, v := range *jsonData.GlobalDomains {
+			// the api can return an empty element in the list
+			if v != "" {
+				globalDomains = append(globalDomains, v)
+			}
+		}
+	}
+	if tfErr := d.Set("global_domains", globalDomains); tfErr != nil {
 		panic(tfErr)
 	}
 	if tfErr := d.Set("parameters", jsonData.Parameters); tfErr != nil {
 		panic(tfErr)
 	}
+	if tfErr := d.Set("parameters_decoded", decodeApplicationParameters(jsonData.Parameters)); tfErr != nil {
+		panic(tfErr)
+	}
+	// keep the order of the api, the evaluation order of the paths on the Bastion
 	paths := make([]map[string]interface{}, 0)
 	if jsonData.Paths != nil {
 		paths = make([]map[string]interface{}, len(*jsonData.Paths))
@@ -527,17 +751,47 @@ func fillApplication(d *schema.ResourceData, jsonData jsonApplication) { //nolin
 	if tfErr := d.Set("paths", paths); tfErr != nil {
 		panic(tfErr)
 	}
+	target := ""
 	if jsonData.Target != nil {
-		if tfErr := d.Set("target", *jsonData.Target); tfErr != nil {
-			panic(tfErr)
-		}
-	} else {
-		if tfErr := d.Set("target", ""); tfErr != nil {
-			panic(tfErr)
+		target = *jsonData.Target
+	}
+	if tfErr := d.Set("target", target); tfErr != nil {
+		panic(tfErr)
+	}
+	targetHost, targetService := splitApplicationTarget(target)
+	if tfErr := d.Set("target_host", targetHost); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("target_service", targetService); tfErr != nil {
+		panic(tfErr)
+	}
+	var listLocalDomains []jsonApplicationLocalDomain
+	if jsonData.LocalDomains != nil {
+		listLocalDomains = slices.Clone(*jsonData.LocalDomains)
+	}
+	// order of local domains returned by the api isn't stable,
+	// keep the order of the configuration (or the state) and sort the others to avoid diff
+	order := make(map[string]int)
+	for i, v := range d.Get("local_domains").([]interface{}) {
+		if localDomain, ok := v.(map[string]interface{}); ok {
+			order[localDomain["domain_name"].(string)] = i + 1
 		}
 	}
-	localDomains := make([]map[string]interface{}, len(*jsonData.LocalDomains))
-	for i, v := range *jsonData.LocalDomains {
+	slices.SortFunc(listLocalDomains, func(a, b jsonApplicationLocalDomain) int {
+		orderA, orderB := order[a.DomainName], order[b.DomainName]
+		switch {
+		case orderA != 0 && orderB != 0:
+			return orderA - orderB
+		case orderA != 0:
+			return -1
+		case orderB != 0:
+			return 1
+		}
+
+		return strings.Compare(a.DomainName, b.DomainName)
+	})
+	localDomains := make([]map[string]interface{}, len(listLocalDomains))
+	for i, v := range listLocalDomains {
 		localDomains[i] = map[string]interface{}{
 			"id":                     v.ID,
 			"admin_account":          v.AdminAccount,
@@ -547,10 +801,60 @@ func fillApplication(d *schema.ResourceData, jsonData jsonApplication) { //nolin
 			"password_change_policy": v.PasswordChangePolicy,
 			"password_change_plugin": v.PasswordChangePlugin,
 		}
-		pluginParameters, _ := json.Marshal(v.PasswordChangePluginParameters) //nolint: errchkjson
-		localDomains[len(localDomains)-1]["password_change_plugin_parameters"] = string(pluginParameters)
+		localDomains[i]["password_change_plugin_parameters"] = ""
+		if v.PasswordChangePluginParameters != nil {
+			pluginParameters, _ := json.Marshal(v.PasswordChangePluginParameters) //nolint: errchkjson
+			localDomains[i]["password_change_plugin_parameters"] = string(pluginParameters)
+		}
 	}
 	if tfErr := d.Set("local_domains", localDomains); tfErr != nil {
 		panic(tfErr)
 	}
 }
+
+// splitApplicationTarget returns the cluster or device part and the service part of target,
+// which is <cluster_name> or <device_name>:<service_name>.
+func splitApplicationTarget(target string) (string, string) {
+	if i := strings.LastIndex(target, ":"); i != -1 {
+		return target[:i], target[i+1:]
+	}
+
+	return target, ""
+}
+
+// decodeApplicationParameters returns the parameters of an application as a map
+// when they are a JSON object or a list of key=value separated by semicolons,
+// and an empty map when the format isn't one of them.
+func decodeApplicationParameters(parameters string) map[string]string {
+	decoded := make(map[string]string)
+	parameters = strings.TrimSpace(parameters)
+	if strings.HasPrefix(parameters, "{") {
+		var object map[string]interface{}
+		if err := json.Unmarshal([]byte(parameters), &object); err != nil {
+			return make(map[string]string)
+		}
+		for k, v := range object {
+			if str, ok := v.(string); ok {
+				decoded[k] = str
+
+				continue
+			}
+			value, _ := json.Marshal(v) //nolint: errchkjson
+			decoded[k] = string(value)
+		}
+
+		return decoded
+	}
+	for _, v := range strings.Split(parameters, ";") {
+		if strings.TrimSpace(v) == "" {
+			continue
+		}
+		key, value, found := strings.Cut(v, "=")
+		if !found || strings.TrimSpace(key) == "" {
+			return make(map[string]string)
+		}
+		decoded[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return decoded
+}
@@ -84,7 +84,7 @@ func resourceApplicationLocalDomainVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_application_localdomain not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_application_localdomain", version)
 }
 
 func resourceApplicationLocalDomainCreate(
@@ -220,19 +220,15 @@ func searchResourceApplicationLocalDomain(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/applications/"+applicationID+
-		"/localdomains/?q=domain_name="+domainName, http.MethodGet, nil)
+	var results []jsonApplicationLocalDomain
+	body, code, err := c.newRequestJSON(ctx, "/applications/"+applicationID+
+		"/localdomains/?q=domain_name="+domainName, http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonApplicationLocalDomain
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
 	if len(results) == 1 {
 		return results[0].ID, true, nil
 	}
@@ -262,8 +258,9 @@ func updateApplicationLocalDomain(
 ) error {
 	c := m.(*Client)
 	jsonData := prepareApplicationLocalDomainJSON(d, false)
-	body, code, err := c.newRequest(ctx,
-		"/applications/"+d.Get("application_id").(string)+"/localdomains/"+d.Id(), http.MethodPut, jsonData)
+	priorJSONData := prepareApplicationLocalDomainJSON(priorResourceData(resourceApplicationLocalDomain(), d), false)
+	body, code, err := c.putIfChanged(ctx,
+		"/applications/"+d.Get("application_id").(string)+"/localdomains/"+d.Id(), jsonData, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -335,7 +332,7 @@ func readApplicationLocalDomainOptions(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
@@ -2,7 +2,6 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -83,7 +82,7 @@ func resourceApplicationLocalDomainAccountVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_application_localdomain_account not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_application_localdomain_account", version)
 }
 
 func resourceApplicationLocalDomainAccountCreate(
@@ -234,19 +233,15 @@ func searchResourceApplicationLocalDomainAccount(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/applications/"+applicationID+"/localdomains/"+domainID+
-		"/accounts/?q=account_name="+accountName, http.MethodGet, nil)
+	var results []jsonApplicationLocalDomainAccount
+	body, code, err := c.newRequestJSON(ctx, "/applications/"+applicationID+"/localdomains/"+domainID+
+		"/accounts/?q=account_name="+accountName, http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonApplicationLocalDomainAccount
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
 	if len(results) == 1 {
 		return results[0].ID, true, nil
 	}
@@ -277,9 +272,11 @@ func updateApplicationLocalDomainAccount(
 ) error {
 	c := m.(*Client)
 	jsonData := prepareApplicationLocalDomainAccountJSON(d)
-	body, code, err := c.newRequest(ctx,
+	prior := priorResourceData(resourceApplicationLocalDomainAccount(), d)
+	priorJSONData := prepareApplicationLocalDomainAccountJSON(prior)
+	body, code, err := c.putIfChanged(ctx,
 		"/applications/"+d.Get("application_id").(string)+"/localdomains/"+d.Get("domain_id").(string)+
-			"/accounts/"+d.Id()+"?force=true", http.MethodPut, jsonData)
+			"/accounts/"+d.Id()+"?force=true", jsonData, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -347,7 +344,7 @@ func readApplicationLocalDomainAccountOptions(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
@@ -1,16 +1,25 @@
 package bastion_test
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"golang.org/x/mod/semver"
 )
 
 func TestAccResourceApplication_basic(t *testing.T) {
+	var applicationID string
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
 		Providers: testAccProviders,
@@ -25,12 +34,533 @@ func TestAccResourceApplication_basic(t *testing.T) {
 			},
 			{
 				Config: testAccResourceApplicationUpdate(),
+				Check: resource.TestCheckResourceAttrWith("wallix-bastion_application.testacc_Appli", "id",
+					func(value string) error {
+						applicationID = value
+
+						return nil
+					}),
 			},
 			{
 				ResourceName:  "wallix-bastion_application.testacc_Appli",
 				ImportState:   true,
 				ImportStateId: "testacc_Appli",
 			},
+			{
+				Config: strings.Replace(testAccResourceApplicationUpdate(),
+					`application_name  = "testacc_Appli"`, `application_name  = "testacc_Appli_renamed"`, 1),
+				Check: resource.TestCheckResourceAttrWith("wallix-bastion_application.testacc_Appli", "id",
+					func(value string) error {
+						if value != applicationID {
+							return fmt.Errorf("id changed from %s to %s after rename", applicationID, value)
+						}
+
+						return nil
+					}),
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+// testApplicationAPI is a fake Bastion for the application resource.
+// A POST creates the application app-id without a body in the response, like the Bastion does,
+// and a GET of app-id returns testApplicationObject with the fields of object.
+type testApplicationAPI struct {
+	// list is the body of the search of the application before its creation, empty by default.
+	list string
+	// object overrides the fields of the application read back.
+	object map[string]interface{}
+	// readStatus, if set, is the status of every read of the application.
+	readStatus int
+	// onPut, if set, is called with the body of a PUT before the application is read back.
+	onPut func(body map[string]interface{})
+
+	posted map[string]interface{}
+	put    map[string]interface{}
+	// reads is the number of reads of the application.
+	reads int
+}
+
+func (api *testApplicationAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet && r.URL.Path == "/api/"+bastion.VersionWallixAPI312+"/applications/app-id" {
+		api.reads++
+	}
+	switch {
+	case r.Method == http.MethodPost:
+		_ = json.NewDecoder(r.Body).Decode(&api.posted)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut:
+		_ = json.NewDecoder(r.Body).Decode(&api.put)
+		if api.onPut != nil {
+			api.onPut(api.put)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case r.URL.Path == "/api/"+bastion.VersionWallixAPI312+"/applications/" && api.posted == nil:
+		_, _ = w.Write([]byte(api.list))
+	case r.URL.Path == "/api/"+bastion.VersionWallixAPI312+"/applications/":
+		_, _ = w.Write([]byte(`[{"id":"other-id","application_name":"app2"},` +
+			`{"id":"app-id","application_name":"app"}]`))
+	case r.URL.Path == "/api/"+bastion.VersionWallixAPI312+"/applications/app-id" && api.readStatus != 0:
+		w.WriteHeader(api.readStatus)
+	case r.URL.Path == "/api/"+bastion.VersionWallixAPI312+"/applications/app-id":
+		_ = json.NewEncoder(w).Encode(testApplicationObject(api.object))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// testApplicationObject returns an application of the Bastion with one path, overridden by fields.
+func testApplicationObject(fields map[string]interface{}) map[string]interface{} {
+	object := map[string]interface{}{
+		"id":                "app-id",
+		"application_name":  "app",
+		"connection_policy": "RDP",
+		"category":          "standard",
+		"description":       "",
+		"parameters":        "",
+		"target":            "cluster",
+		"global_domains":    []interface{}{},
+		"paths": []interface{}{map[string]interface{}{
+			"target": "Interactive@dev:svc", "program": "prog", "working_dir": "",
+		}},
+		"local_domains": []interface{}{},
+	}
+	for k, v := range fields {
+		object[k] = v
+	}
+
+	return object
+}
+
+// testApplicationConfig returns the configuration of an application with one path, overridden by fields.
+func testApplicationConfig(fields map[string]interface{}) map[string]interface{} {
+	cfg := map[string]interface{}{
+		"application_name":  "app",
+		"connection_policy": "RDP",
+		"target":            "cluster",
+		"paths": []interface{}{map[string]interface{}{
+			"target":  "Interactive@dev:svc",
+			"program": "prog",
+		}},
+	}
+	for k, v := range fields {
+		cfg[k] = v
+	}
+
+	return cfg
+}
+
+// testApplicationResource returns the application resource of a provider using api,
+// the data of cfg and the meta of the provider.
+func testApplicationResource(
+	t *testing.T, api *testApplicationAPI, cfg map[string]interface{},
+) (
+	*schema.Resource, *schema.ResourceData, interface{},
+) {
+	t.Helper()
+	provider := testProviderWithServer(t, api)
+	res := provider.ResourcesMap["wallix-bastion_application"]
+
+	return res, schema.TestResourceDataRaw(t, res.Schema, cfg), provider.Meta()
+}
+
+// TestResourceApplication_createNoContent checks the application is read back after a POST without body.
+func TestResourceApplication_createNoContent(t *testing.T) {
+	api := &testApplicationAPI{object: map[string]interface{}{
+		"description":    "from api",
+		"global_domains": []interface{}{"dom1"},
+	}}
+	res, d, meta := testApplicationResource(t, api, testApplicationConfig(map[string]interface{}{
+		"global_domains": []interface{}{"dom1"},
+	}))
+	if diags := res.CreateContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("create with a NoContent response: %v", diags)
+	}
+	if d.Id() != "app-id" {
+		t.Errorf("id = %q, want %q", d.Id(), "app-id")
+	}
+	if v := d.Get("description").(string); v != "from api" {
+		t.Errorf("description = %q, want %q", v, "from api")
+	}
+}
+
+// TestResourceApplication_droppedPaths checks a warning is returned for the paths not kept by the Bastion.
+func TestResourceApplication_droppedPaths(t *testing.T) {
+	res, d, meta := testApplicationResource(t, &testApplicationAPI{}, testApplicationConfig(map[string]interface{}{
+		"paths": []interface{}{
+			map[string]interface{}{"target": "Interactive@dev:svc", "program": "prog"},
+			map[string]interface{}{"target": "Interactive@invalid:svc", "program": "prog"},
+		},
+	}))
+	diags := res.CreateContext(context.Background(), d, meta)
+	if diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning ||
+		!strings.Contains(diags[0].Summary, "target Interactive@invalid:svc") {
+		t.Errorf("diags = %v, want a warning for the path with target Interactive@invalid:svc", diags)
+	}
+}
+
+// TestResourceApplication_createSearchResults checks that an empty search result lets the create proceed
+// but an error of the search stops it before the POST.
+func TestResourceApplication_createSearchResults(t *testing.T) {
+	for name, emptyList := range map[string]string{
+		"empty body": ``,
+		"array":      `[]`,
+		"envelope":   `{"items":[],"total":0}`,
+		"partial":    `[{"id":"other-id","application_name":"app2"}]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			api := &testApplicationAPI{list: emptyList}
+			res, d, meta := testApplicationResource(t, api, testApplicationConfig(nil))
+			if diags := res.CreateContext(context.Background(), d, meta); diags.HasError() {
+				t.Fatalf("create: %v", diags)
+			}
+			if api.posted == nil {
+				t.Error("application not created")
+			}
+			if d.Id() != "app-id" {
+				t.Errorf("id = %q, want %q", d.Id(), "app-id")
+			}
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		api := &testApplicationAPI{list: `{`}
+		res, d, meta := testApplicationResource(t, api, testApplicationConfig(nil))
+		if diags := res.CreateContext(context.Background(), d, meta); !diags.HasError() {
+			t.Error("create after a search error: expected an error")
+		}
+		if api.posted != nil {
+			t.Error("application created after a search error")
+		}
+	})
+}
+
+// TestResourceApplication_rename checks that a rename is an update keeping the id
+// and that a name ignored by the Bastion is reported.
+func TestResourceApplication_rename(t *testing.T) {
+	res := bastion.Provider().ResourcesMap["wallix-bastion_application"]
+	if res.Schema["application_name"].ForceNew {
+		t.Fatal("application_name is ForceNew")
+	}
+	for name, renamed := range map[string]bool{"renamed": true, "ignored": false} {
+		t.Run(name, func(t *testing.T) {
+			api := &testApplicationAPI{object: map[string]interface{}{}}
+			if renamed {
+				api.onPut = func(body map[string]interface{}) {
+					api.object["application_name"] = body["application_name"]
+				}
+			}
+			res, d, meta := testApplicationResource(t, api, testApplicationConfig(map[string]interface{}{
+				"application_name": "app-renamed",
+			}))
+			d.SetId("app-id")
+			diags := res.UpdateContext(context.Background(), d, meta)
+			if api.put["application_name"] != "app-renamed" {
+				t.Errorf("application_name = %v in PUT, want app-renamed", api.put["application_name"])
+			}
+			if api.reads != 1 {
+				t.Errorf("%d reads of the application after the rename, want 1", api.reads)
+			}
+			if renamed {
+				if diags.HasError() {
+					t.Fatalf("update: %v", diags)
+				}
+				if d.Id() != "app-id" {
+					t.Errorf("id = %q after rename, want %q", d.Id(), "app-id")
+				}
+				if v := d.Get("application_name").(string); v != "app-renamed" {
+					t.Errorf("application_name = %q, want %q", v, "app-renamed")
+				}
+			} else if !diags.HasError() || !strings.Contains(diags[0].Summary, "not renamed") {
+				t.Errorf("rename ignored by the Bastion: expected an error, got %v", diags)
+			}
+		})
+	}
+}
+
+func TestResourceApplication_localDomainsSorted(t *testing.T) {
+	res, d, meta := testApplicationResource(t, &testApplicationAPI{object: map[string]interface{}{
+		"paths": []interface{}{},
+		"local_domains": []interface{}{
+			map[string]interface{}{"id": "2", "domain_name": "local2", "password_change_policy": "default"},
+			map[string]interface{}{"id": "1", "domain_name": "local1", "password_change_policy": "default"},
+		},
+	}}, map[string]interface{}{})
+	d.SetId("app-id")
+	if diags := res.ReadContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if v := d.Get("local_domains.0.domain_name").(string); v != "local1" {
+		t.Errorf("local_domains.0.domain_name = %q, want %q", v, "local1")
+	}
+	if v := d.Get("local_domains.1.password_change_policy").(string); v != "default" {
+		t.Errorf("local_domains.1.password_change_policy = %q, want %q", v, "default")
+	}
+}
+
+// TestResourceApplication_localDomains checks the configured local domains are sent in their order
+// and kept in this order when the Bastion returns them in another one.
+func TestResourceApplication_localDomains(t *testing.T) {
+	api := &testApplicationAPI{object: map[string]interface{}{
+		"local_domains": []interface{}{
+			map[string]interface{}{"id": "1", "domain_name": "local1"},
+			map[string]interface{}{
+				"id":                                "2",
+				"domain_name":                       "local2",
+				"enable_password_change":            true,
+				"password_change_policy":            "default",
+				"password_change_plugin":            "Unix",
+				"password_change_plugin_parameters": map[string]interface{}{"port": 22},
+			},
+		},
+	}}
+	res, d, meta := testApplicationResource(t, api, testApplicationConfig(map[string]interface{}{
+		"local_domains": []interface{}{
+			map[string]interface{}{
+				"domain_name":                       "local2",
+				"enable_password_change":            true,
+				"admin_account":                     "admin",
+				"password_change_policy":            "default",
+				"password_change_plugin":            "Unix",
+				"password_change_plugin_parameters": `{"port": 22}`,
+			},
+			map[string]interface{}{"domain_name": "local1"},
+		},
+	}))
+	if diags := res.CreateContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	localDomains, _ := api.posted["local_domains"].([]interface{})
+	if len(localDomains) != 2 {
+		t.Fatalf("local_domains posted = %v, want 2 local domains", api.posted["local_domains"])
+	}
+	first := localDomains[0].(map[string]interface{})
+	if first["domain_name"] != "local2" {
+		t.Errorf("first local domain posted = %v, want local2", first["domain_name"])
+	}
+	if _, ok := first["admin_account"]; ok {
+		t.Errorf("admin_account posted on create: %v", first)
+	}
+	if v, _ := first["password_change_plugin_parameters"].(map[string]interface{}); v["port"] != float64(22) {
+		t.Errorf("password_change_plugin_parameters posted = %v, want an object with port 22",
+			first["password_change_plugin_parameters"])
+	}
+	if v := d.Get("local_domains.0.domain_name").(string); v != "local2" {
+		t.Errorf("local_domains.0.domain_name = %q, want %q", v, "local2")
+	}
+	if v := d.Get("local_domains.1.password_change_plugin_parameters").(string); v != "" {
+		t.Errorf("local_domains.1.password_change_plugin_parameters = %q, want empty", v)
+	}
+}
+
+// TestResourceApplication_pathsOrder checks the paths are sent in the order of the configuration
+// and read in the order of the Bastion: a new plan is empty when the Bastion returns them without trailing spaces
+// but shows a diff when it returns them in another order.
+func TestResourceApplication_pathsOrder(t *testing.T) {
+	dev1 := map[string]interface{}{"target": "Interactive@dev1:svc", "program": "prog1", "working_dir": ""}
+	dev2 := map[string]interface{}{"target": "Interactive@dev2:svc", "program": "prog2", "working_dir": "dir"}
+	for name, tc := range map[string]struct {
+		paths    []interface{}
+		wantDiff bool
+	}{
+		"same order":    {[]interface{}{dev1, dev2}, false},
+		"another order": {[]interface{}{dev2, dev1}, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			api := &testApplicationAPI{object: map[string]interface{}{"paths": tc.paths}}
+			cfg := testApplicationConfig(map[string]interface{}{
+				"paths": []interface{}{
+					map[string]interface{}{"target": "Interactive@dev1:svc", "program": "prog1 "},
+					map[string]interface{}{"target": "Interactive@dev2:svc", "program": "prog2", "working_dir": "dir\t"},
+				},
+			})
+			res, d, meta := testApplicationResource(t, api, cfg)
+			if diags := res.CreateContext(context.Background(), d, meta); diags.HasError() {
+				t.Fatalf("create: %v", diags)
+			}
+			posted, _ := api.posted["paths"].([]interface{})
+			if len(posted) != 2 || posted[0].(map[string]interface{})["target"] != "Interactive@dev1:svc" ||
+				posted[1].(map[string]interface{})["target"] != "Interactive@dev2:svc" {
+				t.Errorf("paths posted = %v, want the order of the configuration", posted)
+			}
+			want := tc.paths[0].(map[string]interface{})["target"].(string)
+			if v := d.Get("paths.0.target").(string); v != want {
+				t.Errorf("paths.0.target = %q, want %q", v, want)
+			}
+			diff, err := res.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(cfg), meta)
+			if err != nil {
+				t.Fatalf("diff: %v", err)
+			}
+			if gotDiff := diff != nil && !diff.Empty(); gotDiff != tc.wantDiff {
+				t.Errorf("diff after create = %v, want a diff: %t", diff, tc.wantDiff)
+			}
+		})
+	}
+}
+
+// TestResourceApplication_threeGlobalDomains checks all the global domains are sent on create
+// and read back from the Bastion.
+func TestResourceApplication_threeGlobalDomains(t *testing.T) {
+	api := &testApplicationAPI{object: map[string]interface{}{
+		"global_domains": []interface{}{"dom1", "dom2", "dom3"},
+	}}
+	res, d, meta := testApplicationResource(t, api, testApplicationConfig(map[string]interface{}{
+		"global_domains": []interface{}{"dom1", "dom2", "dom3"},
+	}))
+	if diags := res.CreateContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if v, _ := api.posted["global_domains"].([]interface{}); len(v) != 3 {
+		t.Errorf("global_domains posted = %v, want [dom1 dom2 dom3]", api.posted["global_domains"])
+	}
+	v := d.Get("global_domains").(*schema.Set)
+	if v.Len() != 3 || !v.Contains("dom1") || !v.Contains("dom2") || !v.Contains("dom3") {
+		t.Errorf("global_domains = %v, want [dom1 dom2 dom3]", v.List())
+	}
+}
+
+func TestResourceApplication_updateReadError(t *testing.T) {
+	api := &testApplicationAPI{readStatus: http.StatusInternalServerError}
+	res, d, meta := testApplicationResource(t, api, testApplicationConfig(nil))
+	d.SetId("app-id")
+	diags := res.UpdateContext(context.Background(), d, meta)
+	if diags.HasError() {
+		t.Fatalf("update with a failed read-back: %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("expected one warning, got %v", diags)
+	}
+	if d.Id() != "app-id" {
+		t.Errorf("id = %q, want %q", d.Id(), "app-id")
+	}
+}
+
+// TestResourceApplication_duplicatePathTargets checks paths with the same target are rejected at plan time.
+func TestResourceApplication_duplicatePathTargets(t *testing.T) {
+	res := bastion.Provider().ResourcesMap["wallix-bastion_application"]
+	config := func(paths ...map[string]interface{}) *terraform.ResourceConfig {
+		list := make([]interface{}, len(paths))
+		for i, v := range paths {
+			list[i] = v
+		}
+
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"application_name":  "app",
+			"connection_policy": "RDP",
+			"target":            "local@server:RDP",
+			"paths":             list,
+		})
+	}
+	notepad := map[string]interface{}{"target": "local@server:RDP", "program": "notepad.exe"}
+	cmd := map[string]interface{}{"target": "local@server:RDP", "program": "cmd.exe"}
+	other := map[string]interface{}{"target": "local@other:RDP", "program": "cmd.exe"}
+
+	if _, err := res.Diff(context.Background(), nil, config(notepad, other), nil); err != nil {
+		t.Errorf("paths with different targets: unexpected error: %v", err)
+	}
+	_, err := res.Diff(context.Background(), nil, config(notepad, cmd, other), nil)
+	if err == nil {
+		t.Fatal("paths with the same target: expected an error")
+	}
+	if !strings.Contains(err.Error(), "target local@server:RDP is configured more than once") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestResourceApplication_parametersDecoded(t *testing.T) {
+	res := bastion.Provider().ResourcesMap["wallix-bastion_application"]
+	for _, tc := range []struct {
+		parameters string
+		want       map[string]string
+	}{
+		{"mode=kiosk; url = https://intranet;", map[string]string{"mode": "kiosk", "url": "https://intranet"}},
+		{`{"mode":"kiosk","timeout":30}`, map[string]string{"mode": "kiosk", "timeout": "30"}},
+		{"-kiosk https://intranet", map[string]string{}},
+	} {
+		diff, err := res.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"application_name":  "app",
+			"connection_policy": "RDP",
+			"target":            "local@server:RDP",
+			"parameters":        tc.parameters,
+			"paths": []interface{}{
+				map[string]interface{}{"target": "local@server:RDP", "program": "browser.exe"},
+			},
+		}), nil)
+		if err != nil {
+			t.Fatalf("diff: %v", err)
+		}
+		for k := range diff.Attributes {
+			key, found := strings.CutPrefix(k, "parameters_decoded.")
+			if _, ok := tc.want[key]; found && key != "%" && !ok {
+				t.Errorf("parameters %q: unexpected parameters_decoded.%s", tc.parameters, key)
+			}
+		}
+		for k, want := range tc.want {
+			if v := diff.Attributes["parameters_decoded."+k]; v == nil || v.New != want {
+				t.Errorf("parameters %q: parameters_decoded.%s = %v, want %q", tc.parameters, k, v, want)
+			}
+		}
+	}
+}
+
+// TestResourceApplication_targetPlanned checks target_host and target_service are planned with a change of target.
+func TestResourceApplication_targetPlanned(t *testing.T) {
+	res := bastion.Provider().ResourcesMap["wallix-bastion_application"]
+	state := &terraform.InstanceState{
+		ID: "app-id",
+		Attributes: map[string]string{
+			"id":                "app-id",
+			"application_name":  "app",
+			"connection_policy": "RDP",
+			"category":          "standard",
+			"target":            "cluster",
+			"target_host":       "cluster",
+			"target_service":    "",
+			"paths.#":           "1",
+			"paths.0.target":    "local@server:RDP",
+			"paths.0.program":   "browser.exe",
+		},
+	}
+	diff, err := res.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"application_name":  "app",
+		"connection_policy": "RDP",
+		"target":            "server:RDP",
+		"paths": []interface{}{
+			map[string]interface{}{"target": "local@server:RDP", "program": "browser.exe"},
+		},
+	}), nil)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	if v := diff.Attributes["target_host"]; v == nil || v.New != "server" {
+		t.Errorf("target_host planned = %v, want %q", v, "server")
+	}
+	if v := diff.Attributes["target_service"]; v == nil || v.New != "RDP" {
+		t.Errorf("target_service planned = %v, want %q", v, "RDP")
+	}
+}
+
+func TestAccResourceApplication_globalDomains(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceApplicationGlobalDomains(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"wallix-bastion_application.testacc_AppliDomains",
+						"global_domains.#", "3"),
+				),
+			},
+			{
+				Config:   testAccResourceApplicationGlobalDomains(),
+				PlanOnly: true,
+			},
 		},
 		PreventPostDestroyRefresh: true,
 	})
@@ -103,6 +633,49 @@ resource "wallix-bastion_application" "testacc_Appli" {
 `
 }
 
+// nolint: lll, nolintlint
+func testAccResourceApplicationGlobalDomains() string {
+	return `
+resource "wallix-bastion_device" "testacc_AppDomains" {
+  device_name = "testacc_AppDomains"
+  host        = "testacc_AppDomains"
+}
+
+resource "wallix-bastion_device_service" "testacc_AppDomains" {
+  device_id         = wallix-bastion_device.testacc_AppDomains.id
+  service_name      = "testacc_AppDomains"
+  connection_policy = "RDP"
+  port              = 22
+  protocol          = "RDP"
+  subprotocols      = ["RDP_CLIPBOARD_UP", "RDP_CLIPBOARD_DOWN"]
+}
+
+resource "wallix-bastion_cluster" "testacc_AppDomains" {
+  cluster_name = "testacc_AppDomains"
+  interactive_logins = [
+    "${wallix-bastion_device.testacc_AppDomains.device_name}:${wallix-bastion_device_service.testacc_AppDomains.service_name}",
+  ]
+}
+
+resource "wallix-bastion_domain" "testacc_AppDomains" {
+  count       = 3
+  domain_name = "testacc_AppDomains${count.index}"
+}
+
+resource "wallix-bastion_application" "testacc_AppliDomains" {
+  application_name  = "testacc_AppliDomains"
+  connection_policy = "RDP"
+  paths {
+    target      = "Interactive@${wallix-bastion_device.testacc_AppDomains.device_name}:${wallix-bastion_device_service.testacc_AppDomains.service_name}"
+    program     = "application_path"
+    working_dir = "directory"
+  }
+  target         = wallix-bastion_cluster.testacc_AppDomains.cluster_name
+  global_domains = wallix-bastion_domain.testacc_AppDomains[*].domain_name
+}
+`
+}
+
 // nolint: lll, nolintlint
 func testAccResourceApplicationCreateJumphost() string {
 	return `
@@ -2,7 +2,6 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"slices"
@@ -124,7 +123,7 @@ func resourceAuthDomainADVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_authdomain_ad not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_authdomain_ad", version)
 }
 
 func resourceAuthDomainADCreate(
@@ -242,18 +241,14 @@ func searchResourceAuthDomainAD(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/authdomains/?q=domain_name="+domainName, http.MethodGet, nil)
+	var results []jsonAuthDomainAD
+	body, code, err := c.newRequestJSON(ctx, "/authdomains/?q=domain_name="+domainName, http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonAuthDomainAD
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
 	if len(results) == 1 {
 		return results[0].ID, true, nil
 	}
@@ -282,7 +277,8 @@ func updateAuthDomainAD(
 ) error {
 	c := m.(*Client)
 	jsonData := prepareAuthDomainADJSON(d)
-	body, code, err := c.newRequest(ctx, "/authdomains/"+d.Id()+"?force=true", http.MethodPut, jsonData)
+	priorJSONData := prepareAuthDomainADJSON(priorResourceData(resourceAuthDomainAD(), d))
+	body, code, err := c.putIfChanged(ctx, "/authdomains/"+d.Id()+"?force=true", jsonData, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -360,7 +356,7 @@ func readAuthDomainADOptions(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
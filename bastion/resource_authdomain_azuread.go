@@ -2,7 +2,6 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"slices"
@@ -119,7 +118,7 @@ func resourceAuthDomainAzureADVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_authdomain_azuread not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_authdomain_azuread", version)
 }
 
 func resourceAuthDomainAzureADCreate(
@@ -237,18 +236,14 @@ func searchResourceAuthDomainAzureAD(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/authdomains/?q=domain_name"+domainName, http.MethodGet, nil)
+	var results []jsonAuthDomainAzureAD
+	body, code, err := c.newRequestJSON(ctx, "/authdomains/?q=domain_name="+domainName, http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonAuthDomainAzureAD
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
 	if len(results) == 1 {
 		return results[0].ID, true, nil
 	}
@@ -277,7 +272,8 @@ func updateAuthDomainAzureAD(
 ) error {
 	c := m.(*Client)
 	jsonData := prepareAuthDomainAzureADJSON(d)
-	body, code, err := c.newRequest(ctx, "/authdomains/"+d.Id()+"?force=true", http.MethodPut, jsonData)
+	priorJSONData := prepareAuthDomainAzureADJSON(priorResourceData(resourceAuthDomainAzureAD(), d))
+	body, code, err := c.putIfChanged(ctx, "/authdomains/"+d.Id()+"?force=true", jsonData, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -353,7 +349,7 @@ func readAuthDomainAzureADOptions(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
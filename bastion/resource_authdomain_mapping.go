@@ -2,7 +2,6 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -60,7 +59,7 @@ func resourceAuthDomainMappingVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_authdomain_mapping not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_authdomain_mapping", version)
 }
 
 func resourceAuthDomainMappingCreate(
@@ -203,7 +202,7 @@ func checkAuthDomainID(
 		return false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
 	var result jsonAuthDomain
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return false, fmt.Errorf("unmarshaling json: %w", err)
 	}
@@ -220,11 +219,13 @@ func searchResourceAuthDomainMapping(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(
+	var results []jsonAuthDomainMapping
+	body, code, err := c.newRequestJSON(
 		ctx,
 		"/authdomains/"+domainID+"/mappings/?q=user_group="+userGroup,
 		http.MethodGet,
 		nil,
+		&results,
 	)
 	if err != nil {
 		return "", false, err
@@ -232,11 +233,6 @@ func searchResourceAuthDomainMapping(
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonAuthDomainMapping
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
 	if len(results) == 1 {
 		return results[0].ID, true, nil
 	}
@@ -270,11 +266,12 @@ func updateAuthDomainMapping(
 ) error {
 	c := m.(*Client)
 	jsonData := prepareAuthDomainMappingJSON(d)
-	body, code, err := c.newRequest(
+	priorJSONData := prepareAuthDomainMappingJSON(priorResourceData(resourceAuthDomainMapping(), d))
+	body, code, err := c.putIfChanged(
 		ctx,
 		"/authdomains/"+d.Get("domain_id").(string)+"/mappings/"+d.Id(),
-		http.MethodPut,
 		jsonData,
+		priorJSONData,
 	)
 	if err != nil {
 		return err
@@ -335,7 +332,7 @@ func readAuthDomainMappingOptions(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
@@ -2,13 +2,16 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"slices"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/mod/semver"
 )
 
 type jsonAuthorization struct {
@@ -32,6 +35,8 @@ type jsonAuthorization struct {
 	ApprovalTimeout            *int      `json:"approval_timeout,omitempty"`
 	Approvers                  *[]string `json:"approvers,omitempty"`
 	SubProtocols               *[]string `json:"subprotocols,omitempty"`
+	AuthorizeSessionSharing    *bool     `json:"authorize_session_sharing,omitempty"`
+	SessionSharingMode         *string   `json:"session_sharing_mode,omitempty"`
 }
 
 func resourceAuthorization() *schema.Resource {
@@ -43,11 +48,17 @@ func resourceAuthorization() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceAuthorizationImport,
 		},
+		CustomizeDiff: resourceAuthorizationCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"authorization_name": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"allow_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
 			"user_group": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -86,6 +97,17 @@ func resourceAuthorization() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+			"authorize_session_sharing": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"session_sharing_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"authorize_session_sharing"},
+				ValidateFunc: validation.StringInSlice([]string{"view_only", "view_control"}, false),
+			},
 			"approval_required": {
 				Type:         schema.TypeBool,
 				Optional:     true,
@@ -139,6 +161,10 @@ func resourceAuthorization() *schema.Resource {
 				Optional:     true,
 				RequiredWith: []string{"approval_required"},
 			},
+			"href": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -148,7 +174,25 @@ func resourceAuthorizationVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_authorization not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_authorization", version)
+}
+
+// resourceAuthorizationCustomizeDiff checks the dependencies between arguments
+// and reports all the problems at once.
+func resourceAuthorizationCustomizeDiff(
+	_ context.Context, d *schema.ResourceDiff, _ interface{},
+) error {
+	var errs []error
+	if d.Get("authorize_sessions").(bool) && d.NewValueKnown("subprotocols") &&
+		d.Get("subprotocols").(*schema.Set).Len() == 0 {
+		errs = append(errs, errors.New("subprotocols can't be empty with authorize_sessions = true"))
+	}
+	if d.Get("approval_required").(bool) && d.NewValueKnown("approvers") &&
+		len(d.Get("approvers").([]interface{})) == 0 {
+		errs = append(errs, errors.New("approvers can't be empty with approval_required = true"))
+	}
+
+	return errors.Join(errs...)
 }
 
 func resourceAuthorizationCreate(
@@ -196,6 +240,9 @@ func resourceAuthorizationRead(
 		d.SetId("")
 	} else {
 		fillAuthorization(d, cfg)
+		if tfErr := d.Set("href", c.apiURL("/authorizations/"+url.PathEscape(d.Id()))); tfErr != nil {
+			panic(tfErr)
+		}
 	}
 
 	return nil
@@ -209,8 +256,10 @@ func resourceAuthorizationUpdate(
 	if err := resourceAuthorizationVersionCheck(c.bastionAPIVersion); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := updateAuthorization(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+	if d.HasChangesExcept("allow_destroy") {
+		if err := updateAuthorization(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 	d.Partial(false)
 
@@ -224,6 +273,9 @@ func resourceAuthorizationDelete(
 	if err := resourceAuthorizationVersionCheck(c.bastionAPIVersion); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := checkAllowDestroy(d, "wallix-bastion_authorization"); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := deleteAuthorization(ctx, d, m); err != nil {
 		return diag.FromErr(err)
 	}
@@ -253,6 +305,9 @@ func resourceAuthorizationImport(
 		return nil, err
 	}
 	fillAuthorization(d, cfg)
+	if tfErr := d.Set("allow_destroy", true); tfErr != nil {
+		panic(tfErr)
+	}
 	result := make([]*schema.ResourceData, 1)
 	d.SetId(id)
 	result[0] = d
@@ -266,18 +321,15 @@ func searchResourceAuthorization(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/authorizations/?q=authorization_name="+authorizationName, http.MethodGet, nil)
+	var results []jsonAuthorization
+	body, code, err := c.newRequestJSON(ctx,
+		"/authorizations/?q=authorization_name="+authorizationName, http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonAuthorization
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
 	if len(results) == 1 {
 		return results[0].ID, true, nil
 	}
@@ -289,7 +341,10 @@ func addAuthorization(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) error {
 	c := m.(*Client)
-	jsonData := prepareAuthorizationJSON(d, true)
+	jsonData, err := prepareAuthorizationJSON(d, true, c.bastionAPIVersion)
+	if err != nil {
+		return err
+	}
 	body, code, err := c.newRequest(ctx, "/authorizations/", http.MethodPost, jsonData)
 	if err != nil {
 		return err
@@ -305,8 +360,16 @@ func updateAuthorization(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) error {
 	c := m.(*Client)
-	jsonData := prepareAuthorizationJSON(d, false)
-	body, code, err := c.newRequest(ctx, "/authorizations/"+d.Id()+"?force=true", http.MethodPut, jsonData)
+	jsonData, err := prepareAuthorizationJSON(d, false, c.bastionAPIVersion)
+	if err != nil {
+		return err
+	}
+	prior := priorResourceData(resourceAuthorization(), d)
+	var priorJSONData interface{}
+	if v, err := prepareAuthorizationJSON(prior, false, c.bastionAPIVersion); err == nil {
+		priorJSONData = v
+	}
+	body, code, err := c.putIfChanged(ctx, "/authorizations/"+d.Id()+"?force=true", jsonData, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -332,7 +395,11 @@ func deleteAuthorization(
 	return nil
 }
 
-func prepareAuthorizationJSON(d *schema.ResourceData, newResource bool) jsonAuthorization {
+func prepareAuthorizationJSON(
+	d *schema.ResourceData, newResource bool, apiVersion string,
+) (
+	jsonAuthorization, error,
+) {
 	jsonData := jsonAuthorization{
 		AuthorizationName:          d.Get("authorization_name").(string),
 		AuthorizePasswordRetrieval: d.Get("authorize_password_retrieval").(bool),
@@ -381,7 +448,17 @@ func prepareAuthorizationJSON(d *schema.ResourceData, newResource bool) jsonAuth
 		jsonData.SubProtocols = &subProtocols
 	}
 
-	return jsonData
+	if semver.Compare(apiVersion, VersionWallixAPI312) >= 0 {
+		authorizeSessionSharing := d.Get("authorize_session_sharing").(bool)
+		jsonData.AuthorizeSessionSharing = &authorizeSessionSharing
+		if v := d.Get("session_sharing_mode").(string); authorizeSessionSharing && v != "" {
+			jsonData.SessionSharingMode = &v
+		}
+	} else if d.Get("authorize_session_sharing").(bool) {
+		return jsonData, fmt.Errorf("authorize_session_sharing not available with api version %s", apiVersion)
+	}
+
+	return jsonData, nil
 }
 
 func readAuthorizationOptions(
@@ -401,7 +478,7 @@ func readAuthorizationOptions(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
@@ -437,6 +514,16 @@ func fillAuthorization(d *schema.ResourceData, jsonData jsonAuthorization) {
 	if tfErr := d.Set("is_recorded", jsonData.IsRecorded); tfErr != nil {
 		panic(tfErr)
 	}
+	if jsonData.AuthorizeSessionSharing != nil {
+		if tfErr := d.Set("authorize_session_sharing", *jsonData.AuthorizeSessionSharing); tfErr != nil {
+			panic(tfErr)
+		}
+	}
+	if jsonData.SessionSharingMode != nil {
+		if tfErr := d.Set("session_sharing_mode", *jsonData.SessionSharingMode); tfErr != nil {
+			panic(tfErr)
+		}
+	}
 	if tfErr := d.Set("approval_required", jsonData.ApprovalRequired); tfErr != nil {
 		panic(tfErr)
 	}
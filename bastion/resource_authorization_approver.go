@@ -0,0 +1,193 @@
+package bastion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceAuthorizationApprover() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAuthorizationApproverCreate,
+		ReadContext:   resourceAuthorizationApproverRead,
+		DeleteContext: resourceAuthorizationApproverDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAuthorizationApproverImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"authorization_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"approver": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAuthorizationApproverVersionCheck(version string) error {
+	if slices.Contains(defaultVersionsValid(), version) {
+		return nil
+	}
+
+	return versionNotAvailableError("resource", "wallix-bastion_authorization_approver", version)
+}
+
+func resourceAuthorizationApproverCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceAuthorizationApproverVersionCheck(c.bastionAPIVersion); err != nil {
+		return diag.FromErr(err)
+	}
+	authorizationID := d.Get("authorization_id").(string)
+	approver := d.Get("approver").(string)
+	c.approversMutex.Lock()
+	defer c.approversMutex.Unlock()
+	cfg, err := readAuthorizationOptions(ctx, authorizationID, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if cfg.ID == "" {
+		return diag.FromErr(fmt.Errorf("authorization with ID %s doesn't exists", authorizationID))
+	}
+	if !cfg.ApprovalRequired {
+		return diag.FromErr(fmt.Errorf("authorization %s doesn't require approval, "+
+			"set approval_required on it before adding approvers", cfg.AuthorizationName))
+	}
+	approvers := make([]string, 0)
+	if cfg.Approvers != nil {
+		approvers = *cfg.Approvers
+	}
+	if slices.Contains(approvers, approver) {
+		return diag.FromErr(fmt.Errorf("approver %s on authorization %s already exists",
+			approver, cfg.AuthorizationName))
+	}
+	approvers = append(approvers, approver)
+	if err := updateAuthorizationApprovers(ctx, cfg, approvers, m); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(authorizationID + "/" + approver)
+
+	return resourceAuthorizationApproverRead(ctx, d, m)
+}
+
+func resourceAuthorizationApproverRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceAuthorizationApproverVersionCheck(c.bastionAPIVersion); err != nil {
+		return diag.FromErr(err)
+	}
+	cfg, err := readAuthorizationOptions(ctx, d.Get("authorization_id").(string), m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if cfg.ID == "" || cfg.Approvers == nil || !slices.Contains(*cfg.Approvers, d.Get("approver").(string)) {
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceAuthorizationApproverDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceAuthorizationApproverVersionCheck(c.bastionAPIVersion); err != nil {
+		return diag.FromErr(err)
+	}
+	approver := d.Get("approver").(string)
+	c.approversMutex.Lock()
+	defer c.approversMutex.Unlock()
+	cfg, err := readAuthorizationOptions(ctx, d.Get("authorization_id").(string), m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// authorization or approver already removed outside of terraform
+	if cfg.ID == "" || cfg.Approvers == nil || !slices.Contains(*cfg.Approvers, approver) {
+		return nil
+	}
+	approvers := slices.DeleteFunc(slices.Clone(*cfg.Approvers), func(v string) bool {
+		return v == approver
+	})
+	if err := updateAuthorizationApprovers(ctx, cfg, approvers, m); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceAuthorizationApproverImport(
+	d *schema.ResourceData, m interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceAuthorizationApproverVersionCheck(c.bastionAPIVersion); err != nil {
+		return nil, err
+	}
+	idSplit := strings.Split(d.Id(), "/")
+	if len(idSplit) != 2 {
+		return nil, errors.New("id must be <authorization_name>/<approver>")
+	}
+	id, ex, err := searchResourceAuthorization(ctx, idSplit[0], m)
+	if err != nil {
+		return nil, err
+	}
+	if !ex {
+		return nil, fmt.Errorf("don't find authorization_name with id %s (id must be <authorization_name>/<approver>)",
+			idSplit[0])
+	}
+	cfg, err := readAuthorizationOptions(ctx, id, m)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Approvers == nil || !slices.Contains(*cfg.Approvers, idSplit[1]) {
+		return nil, fmt.Errorf("don't find approver %s on authorization %s", idSplit[1], idSplit[0])
+	}
+	if tfErr := d.Set("authorization_id", id); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("approver", idSplit[1]); tfErr != nil {
+		panic(tfErr)
+	}
+	result := make([]*schema.ResourceData, 1)
+	d.SetId(id + "/" + idSplit[1])
+	result[0] = d
+
+	return result, nil
+}
+
+// updateAuthorizationApprovers puts the authorization read in cfg with only its approvers changed.
+func updateAuthorizationApprovers(
+	ctx context.Context, cfg jsonAuthorization, approvers []string, m interface{},
+) error {
+	c := m.(*Client)
+	authorizationID := cfg.ID
+	// the id, user group and target group can't be sent on update
+	cfg.ID = ""
+	cfg.UserGroup = ""
+	cfg.TargetGroup = ""
+	cfg.Approvers = &approvers
+	body, code, err := c.newRequest(ctx, "/authorizations/"+authorizationID+"?force=true", http.MethodPut, cfg)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK && code != http.StatusNoContent {
+		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+	}
+
+	return nil
+}
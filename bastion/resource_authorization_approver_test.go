@@ -0,0 +1,80 @@
+package bastion_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestResourceAuthorizationApprover_basic checks that only the approvers of the authorization are changed.
+func TestResourceAuthorizationApprover_basic(t *testing.T) {
+	approvers := []string{"owners"}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/authorizations/auth-id",
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPut {
+				var body map[string]interface{}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Fatal(err)
+				}
+				if body["authorization_name"] != "auth" || body["is_critical"] != true {
+					t.Errorf("other arguments of the authorization not kept: %v", body)
+				}
+				if _, ok := body["user_group"]; ok {
+					t.Errorf("user_group sent on update: %v", body)
+				}
+				approvers = nil
+				for _, v := range body["approvers"].([]interface{}) {
+					approvers = append(approvers, v.(string))
+				}
+				w.WriteHeader(http.StatusNoContent)
+
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":                 "auth-id",
+				"authorization_name": "auth",
+				"user_group":         "users",
+				"target_group":       "targets",
+				"approval_required":  true,
+				"is_critical":        true,
+				"approvers":          approvers,
+			})
+		})
+	provider := testProviderWithServer(t, mux)
+	res := provider.ResourcesMap["wallix-bastion_authorization_approver"]
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"authorization_id": "auth-id",
+		"approver":         "security",
+	})
+	if diags := res.CreateContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "auth-id/security" {
+		t.Errorf("id = %q, want %q", d.Id(), "auth-id/security")
+	}
+	if !slices.Equal(approvers, []string{"owners", "security"}) {
+		t.Errorf("approvers after create = %v, want [owners security]", approvers)
+	}
+	if diags := res.CreateContext(context.Background(), d, provider.Meta()); !diags.HasError() {
+		t.Error("create of an existing approver: expected an error")
+	}
+	if diags := res.DeleteContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if !slices.Equal(approvers, []string{"owners"}) {
+		t.Errorf("approvers after delete = %v, want [owners]", approvers)
+	}
+	if diags := res.ReadContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("id = %q after the approver is removed, want empty", d.Id())
+	}
+}
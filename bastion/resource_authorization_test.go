@@ -1,9 +1,17 @@
 package bastion_test
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccResourceAuthorization_basic(t *testing.T) {
@@ -32,6 +40,67 @@ func TestAccResourceAuthorization_basic(t *testing.T) {
 	})
 }
 
+func TestResourceAuthorization_customizeDiff(t *testing.T) {
+	res := bastion.Provider().ResourcesMap["wallix-bastion_authorization"]
+	_, err := res.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"authorization_name": "auth",
+		"user_group":         "ug",
+		"target_group":       "tg",
+		"authorize_sessions": true,
+		"subprotocols":       []interface{}{},
+		"approval_required":  true,
+		"approvers":          []interface{}{},
+	}), nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	// all the problems are reported at once
+	for _, want := range []string{"subprotocols", "approvers"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error doesn't report %s: %v", want, err)
+		}
+	}
+}
+
+func TestResourceAuthorization_sessionSharing(t *testing.T) {
+	var sent map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/authorizations/auth-id", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+				t.Errorf("decoding PUT body: %v", err)
+			}
+			w.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"auth-id","authorization_name":"auth","user_group":"ug","target_group":"tg",` +
+			`"authorize_sessions":true,"subprotocols":["SSH_SHELL_SESSION"],` +
+			`"authorize_session_sharing":true,"session_sharing_mode":"view_control"}`))
+	})
+	provider := testProviderWithServer(t, mux)
+	res := provider.ResourcesMap["wallix-bastion_authorization"]
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"authorization_name":        "auth",
+		"user_group":                "ug",
+		"target_group":              "tg",
+		"authorize_sessions":        true,
+		"subprotocols":              []interface{}{"SSH_SHELL_SESSION"},
+		"authorize_session_sharing": true,
+		"session_sharing_mode":      "view_control",
+	})
+	d.SetId("auth-id")
+	if diags := res.UpdateContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if sent["authorize_session_sharing"] != true || sent["session_sharing_mode"] != "view_control" {
+		t.Errorf("session sharing not sent in PUT body: %v", sent)
+	}
+	if v := d.Get("session_sharing_mode").(string); v != "view_control" {
+		t.Errorf("session_sharing_mode = %q, want %q", v, "view_control")
+	}
+}
+
 // nolint: lll, nolintlint
 func testAccResourceAuthorizationCreate() string {
 	return `
@@ -2,7 +2,6 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"slices"
@@ -74,7 +73,7 @@ func resourceCheckoutPolicyVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_checkout_policy not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_checkout_policy", version)
 }
 
 func resourceCheckoutPolicyCreate(
@@ -193,19 +192,15 @@ func searchResourceCheckoutPolicy(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx,
-		"/checkoutpolicies/?q=checkout_policy_name="+checkoutPolicyName, http.MethodGet, nil)
+	var results []jsonCheckoutPolicy
+	body, code, err := c.newRequestJSON(ctx,
+		"/checkoutpolicies/?q=checkout_policy_name="+checkoutPolicyName, http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonCheckoutPolicy
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
 	if len(results) == 1 {
 		return results[0].ID, true, nil
 	}
@@ -234,7 +229,8 @@ func updateCheckoutPolicy(
 ) error {
 	c := m.(*Client)
 	jsonData := prepareCheckoutPolicyJSON(d)
-	body, code, err := c.newRequest(ctx, "/checkoutpolicies/"+d.Id(), http.MethodPut, jsonData)
+	priorJSONData := prepareCheckoutPolicyJSON(priorResourceData(resourceCheckoutPolicy(), d))
+	body, code, err := c.putIfChanged(ctx, "/checkoutpolicies/"+d.Id(), jsonData, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -291,7 +287,7 @@ func readCheckoutPolicyOptions(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
@@ -2,7 +2,6 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"slices"
@@ -65,7 +64,7 @@ func resourceClusterVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_cluster not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_cluster", version)
 }
 
 func resourceClusterCreate(
@@ -183,18 +182,14 @@ func searchResourceCluster(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/clusters/?q=cluster_name="+clusterName, http.MethodGet, nil)
+	var results []jsonCluster
+	body, code, err := c.newRequestJSON(ctx, "/clusters/?q=cluster_name="+clusterName, http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonCluster
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
 	if len(results) == 1 {
 		return results[0].ID, true, nil
 	}
@@ -223,7 +218,8 @@ func updateCluster(
 ) error {
 	c := m.(*Client)
 	jsonData := prepareClusterJSON(d)
-	body, code, err := c.newRequest(ctx, "/clusters/"+d.Id()+"?force=true", http.MethodPut, jsonData)
+	priorJSONData := prepareClusterJSON(priorResourceData(resourceCluster(), d))
+	body, code, err := c.putIfChanged(ctx, "/clusters/"+d.Id()+"?force=true", jsonData, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -293,7 +289,7 @@ func readClusterOptions(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
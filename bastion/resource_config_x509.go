@@ -2,9 +2,10 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -26,6 +27,7 @@ func resourceConfigX509() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceConfigX509Import,
 		},
+		CustomizeDiff: resourceConfigX509CustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"ca_certificate": {
 				Type:     schema.TypeString,
@@ -47,6 +49,18 @@ func resourceConfigX509() *schema.Resource {
 	}
 }
 
+// resourceConfigX509CustomizeDiff refuses to enable the X509 users authentication without the CA
+// which has signed the certificates of the users.
+func resourceConfigX509CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Get("enable").(bool) && d.NewValueKnown("ca_certificate") &&
+		strings.TrimSpace(d.Get("ca_certificate").(string)) == "" {
+		return errors.New("ca_certificate need to be set with enable = true, " +
+			"the X509 users authentication checks the certificates of the users with this CA")
+	}
+
+	return nil
+}
+
 func resourceConfigX509Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Add the configuration
 	if err := addConfigX509(ctx, d, m); err != nil {
@@ -129,7 +143,7 @@ func readConfigX509Options(ctx context.Context, m interface{}) (jsonConfigX509,
 	if code != http.StatusOK {
 		return result, fmt.Errorf("API returned error: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("error unmarshaling JSON: %w", err)
 	}
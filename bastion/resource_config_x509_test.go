@@ -1,11 +1,36 @@
 package bastion_test
 
 import (
+	"context"
+	"strings"
 	"testing"
 
+	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
+func TestResourceConfigX509_enableWithoutCA(t *testing.T) {
+	res := bastion.Provider().ResourcesMap["wallix-bastion_config_x509"]
+	_, err := res.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"server_public_key":  "public-key",
+		"server_private_key": "private-key",
+		"ca_certificate":     " ",
+		"enable":             true,
+	}), nil)
+	if err == nil || !strings.Contains(err.Error(), "ca_certificate") {
+		t.Errorf("expected an error about ca_certificate, got %v", err)
+	}
+	_, err = res.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"server_public_key":  "public-key",
+		"server_private_key": "private-key",
+	}), nil)
+	if err != nil {
+		t.Errorf("unexpected error without the X509 users authentication: %v", err)
+	}
+}
+
 // TestAccResourceConfigX509_basic tests creating, updating the x509 configuration.
 func TestAccResourceConfigX509_basic(t *testing.T) {
 	resourceName := "bastion_x509_config.test"
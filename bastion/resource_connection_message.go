@@ -2,7 +2,6 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"slices"
@@ -52,7 +51,7 @@ func resourceConnectionMessageVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_connection_message not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_connection_message", version)
 }
 
 func resourceConnectionMessageCreate(
@@ -135,11 +134,11 @@ func updateConnectionMessage(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) error {
 	c := m.(*Client)
-	body, code, err := c.newRequest(
+	body, code, err := c.putIfChanged(
 		ctx,
 		"/connectionmessages/"+d.Get("message_name").(string),
-		http.MethodPut,
 		prepareConnectionMessageJSON(d),
+		prepareConnectionMessageJSON(priorResourceData(resourceConnectionMessage(), d)),
 	)
 	if err != nil {
 		return err
@@ -176,7 +175,7 @@ func readConnectionMessage(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
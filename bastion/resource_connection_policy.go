@@ -3,8 +3,10 @@ package bastion
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"slices"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -32,6 +34,7 @@ func resourceConnectionPolicy() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceConnectionPolicyImport,
 		},
+		CustomizeDiff: resourceConnectionPolicyCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"connection_policy_name": {
 				Type:     schema.TypeString,
@@ -60,15 +63,22 @@ func resourceConnectionPolicy() *schema.Resource {
 				Optional: true,
 			},
 			"authentication_methods": {
-				Type:     schema.TypeSet,
+				Type:     schema.TypeList,
 				Optional: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(validAuthenticationMethods(), false),
+				},
 			},
 			"options": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ValidateFunc: validation.StringIsJSON,
 			},
+			"href": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -78,7 +88,53 @@ func resourceConnectionPolicyVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_connection_policy not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_connection_policy", version)
+}
+
+// resourceConnectionPolicyCustomizeDiff checks the dependencies between arguments
+// and reports all the problems at once.
+func resourceConnectionPolicyCustomizeDiff(
+	_ context.Context, d *schema.ResourceDiff, _ interface{},
+) error {
+	var errs []error
+	// type not configured keeps the value of the state, which isn't the type of a new protocol
+	if d.NewValueKnown("protocol") && d.NewValueKnown("type") && connectionPolicyTypeConfigured(d) {
+		protocol := d.Get("protocol").(string)
+		switch typ := d.Get("type").(string); {
+		case typ == "", typ == protocol:
+		case typ == "RDP-JUMPHOST" && protocol == "RDP":
+		default:
+			errs = append(errs, fmt.Errorf("type %s isn't compatible with protocol %s", typ, protocol))
+		}
+	}
+	if d.NewValueKnown("authentication_methods") {
+		seen := make(map[string]bool)
+		for _, v := range d.Get("authentication_methods").([]interface{}) {
+			if seen[v.(string)] {
+				errs = append(errs, fmt.Errorf("authentication_methods: %s is defined several times", v.(string)))
+			}
+			seen[v.(string)] = true
+		}
+	}
+	if v := d.Get("options").(string); d.NewValueKnown("options") && v != "" {
+		var options map[string]interface{}
+		if err := json.Unmarshal([]byte(v), &options); err != nil {
+			errs = append(errs, fmt.Errorf("options need to be a JSON object: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// connectionPolicyTypeConfigured returns true when type is in the configuration
+// or, without the configuration (like in the tests), when type is planned with a new value.
+func connectionPolicyTypeConfigured(d *schema.ResourceDiff) bool {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsKnown() && !rawConfig.IsNull() {
+		return !rawConfig.GetAttr("type").IsNull()
+	}
+
+	return d.Id() == "" || d.HasChange("type")
 }
 
 func resourceConnectionPolicyCreate(
@@ -127,6 +183,9 @@ func resourceConnectionPolicyRead(
 		d.SetId("")
 	} else {
 		fillConnectionPolicy(d, cfg)
+		if tfErr := d.Set("href", c.apiURL("/connectionpolicies/"+url.PathEscape(d.Id()))); tfErr != nil {
+			panic(tfErr)
+		}
 	}
 
 	return nil
@@ -197,19 +256,15 @@ func searchResourceConnectionPolicy(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx,
-		"/connectionpolicies/?q=connection_policy_name="+connectionPolicyName, http.MethodGet, nil)
+	var results []jsonConnectionPolicy
+	body, code, err := c.newRequestJSON(ctx,
+		"/connectionpolicies/?q=connection_policy_name="+connectionPolicyName, http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonConnectionPolicy
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
 	if len(results) == 1 {
 		return results[0].ID, true, nil
 	}
@@ -244,7 +299,12 @@ func updateConnectionPolicy(
 	if err != nil {
 		return err
 	}
-	body, code, err := c.newRequest(ctx, "/connectionpolicies/"+d.Id()+"?force=true", http.MethodPut, jsonData)
+	prior := priorResourceData(resourceConnectionPolicy(), d)
+	var priorJSONData interface{}
+	if v, err := prepareConnectionPolicyJSON(prior, false, apiVersion); err == nil {
+		priorJSONData = v
+	}
+	body, code, err := c.putIfChanged(ctx, "/connectionpolicies/"+d.Id()+"?force=true", jsonData, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -263,6 +323,12 @@ func deleteConnectionPolicy(
 	if err != nil {
 		return err
 	}
+	if code == http.StatusConflict {
+		// best effort to list the objects using the policy, the error of the api is returned otherwise
+		usedBy, _ := searchConnectionPolicyUsers(ctx, d.Get("connection_policy_name").(string), m)
+
+		return inUseDeleteError("connection policy", d.Get("connection_policy_name").(string), body, usedBy)
+	}
 	if code != http.StatusOK && code != http.StatusNoContent {
 		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
 	}
@@ -270,6 +336,49 @@ func deleteConnectionPolicy(
 	return nil
 }
 
+// searchConnectionPolicyUsers returns the applications and the device services using a connection policy.
+func searchConnectionPolicyUsers(
+	ctx context.Context, connectionPolicyName string, m interface{},
+) (
+	[]string, error,
+) {
+	c := m.(*Client)
+	usedBy := make([]string, 0)
+	applications, err := c.listAll(ctx, "/applications/", url.Values{"q": {"connection_policy=" + connectionPolicyName}})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range applications {
+		var application jsonApplication
+		if err := c.unmarshalJSON(ctx, string(v), &application); err != nil {
+			return nil, fmt.Errorf("unmarshaling json: %w", err)
+		}
+		if application.ConnectionPolicy == connectionPolicyName {
+			usedBy = append(usedBy, "application "+application.ApplicationName)
+		}
+	}
+	devices, err := c.listAll(ctx, "/devices/", nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range devices {
+		var device jsonDevice
+		if err := c.unmarshalJSON(ctx, string(v), &device); err != nil {
+			return nil, fmt.Errorf("unmarshaling json: %w", err)
+		}
+		if device.Services == nil {
+			continue
+		}
+		for _, service := range *device.Services {
+			if service.ConnectionPolicy == connectionPolicyName {
+				usedBy = append(usedBy, "service "+service.ServiceName+" of device "+device.DeviceName)
+			}
+		}
+	}
+
+	return usedBy, nil
+}
+
 func prepareConnectionPolicyJSON(
 	d *schema.ResourceData, newResource bool, apiVersion string,
 ) (
@@ -290,7 +399,8 @@ func prepareConnectionPolicyJSON(
 		}
 	}
 
-	listAuthenticationMethods := d.Get("authentication_methods").(*schema.Set).List()
+	// order of authentication methods is the fallback order so keep it
+	listAuthenticationMethods := d.Get("authentication_methods").([]interface{})
 	jsonData.AuthenticationMethods = make([]string, len(listAuthenticationMethods))
 	for i, v := range listAuthenticationMethods {
 		if !slices.Contains(validAuthenticationMethods(), v.(string)) {
@@ -338,7 +448,7 @@ func readConnectionPolicyOptions(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
@@ -1,9 +1,19 @@
 package bastion_test
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccResourceConnectionPolicy_basic(t *testing.T) {
@@ -33,6 +43,156 @@ func TestAccResourceConnectionPolicy_basic(t *testing.T) {
 }
 
 // nolint: lll, nolintlint
+// TestResourceConnectionPolicy_deleteInUse checks that the objects using a connection policy
+// are listed when its deletion is refused.
+func TestResourceConnectionPolicy_deleteInUse(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/connectionpolicies/cp-id",
+		func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error":"Conflict"}`))
+		})
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/applications/", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[{"application_name":"app","connection_policy":"cp"}]`))
+	})
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/devices/", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[{"device_name":"dev","services":[` +
+			`{"service_name":"ssh","connection_policy":"cp"},{"service_name":"rdp","connection_policy":"RDP"}]}]`))
+	})
+	provider := testProviderWithServer(t, mux)
+	res := provider.ResourcesMap["wallix-bastion_connection_policy"]
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"connection_policy_name": "cp",
+		"protocol":               "SSH",
+	})
+	d.SetId("cp-id")
+	diags := res.DeleteContext(context.Background(), d, provider.Meta())
+	if !diags.HasError() {
+		t.Fatal("delete refused by the api: expected an error")
+	}
+	if want := "used by: application app, service ssh of device dev"; !strings.Contains(diags[0].Summary, want) {
+		t.Errorf("error %q doesn't contain %q", diags[0].Summary, want)
+	}
+}
+
+// TestResourceConnectionPolicy_authenticationMethodsOrder checks the authentication methods are sent
+// in the order of the configuration and set in the order returned by the api.
+func TestResourceConnectionPolicy_authenticationMethodsOrder(t *testing.T) {
+	var methods []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/connectionpolicies/",
+		func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodPost:
+				var body struct {
+					AuthenticationMethods []string `json:"authentication_methods"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Fatal(err)
+				}
+				methods = body.AuthenticationMethods
+				w.WriteHeader(http.StatusNoContent)
+			case r.URL.Path == "/api/"+bastion.VersionWallixAPI312+"/connectionpolicies/":
+				if methods == nil {
+					_, _ = w.Write([]byte(`[]`))
+
+					return
+				}
+				_, _ = w.Write([]byte(`[{"id":"cp-id","connection_policy_name":"cp"}]`))
+			default:
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"id":                     "cp-id",
+					"connection_policy_name": "cp",
+					"protocol":               "SSH",
+					"type":                   "SSH",
+					"options":                map[string]interface{}{},
+					"authentication_methods": methods,
+				})
+			}
+		})
+	provider := testProviderWithServer(t, mux)
+	res := provider.ResourcesMap["wallix-bastion_connection_policy"]
+	configured := []string{"PUBKEY_VAULT", "PASSWORD_VAULT", "PASSWORD_INTERACTIVE"}
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"connection_policy_name": "cp",
+		"protocol":               "SSH",
+		"authentication_methods": []interface{}{configured[0], configured[1], configured[2]},
+	})
+	if diags := res.CreateContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if !slices.Equal(methods, configured) {
+		t.Errorf("authentication_methods posted = %v, want %v", methods, configured)
+	}
+	for i, want := range configured {
+		if v := d.Get(fmt.Sprintf("authentication_methods.%d", i)).(string); v != want {
+			t.Errorf("authentication_methods.%d = %q after create, want %q", i, v, want)
+		}
+	}
+
+	// a change of the fallback order on the Bastion is read as it is
+	methods = []string{"PASSWORD_INTERACTIVE", "PUBKEY_VAULT", "PASSWORD_VAULT"}
+	if diags := res.ReadContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if v := d.Get("authentication_methods.0").(string); v != "PASSWORD_INTERACTIVE" {
+		t.Errorf("authentication_methods.0 = %q after a change of order, want %q", v, "PASSWORD_INTERACTIVE")
+	}
+}
+
+// TestResourceConnectionPolicy_protocolChange checks the type of the state isn't checked against a new protocol
+// when type isn't configured, but a configured type is.
+func TestResourceConnectionPolicy_protocolChange(t *testing.T) {
+	res := bastion.Provider().ResourcesMap["wallix-bastion_connection_policy"]
+	state := &terraform.InstanceState{
+		ID: "cp-id",
+		Attributes: map[string]string{
+			"id":                     "cp-id",
+			"connection_policy_name": "cp",
+			"protocol":               "SSH",
+			"type":                   "SSH",
+		},
+	}
+	if _, err := res.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"connection_policy_name": "cp",
+		"protocol":               "RDP",
+	}), nil); err != nil {
+		t.Errorf("change of protocol without type: %v", err)
+	}
+	_, err := res.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"connection_policy_name": "cp",
+		"protocol":               "RDP",
+		"type":                   "VNC",
+	}), nil)
+	if err == nil || !strings.Contains(err.Error(), "type VNC isn't compatible with protocol RDP") {
+		t.Errorf("type VNC with protocol RDP: expected an error, got %v", err)
+	}
+}
+
+func TestResourceConnectionPolicy_customizeDiff(t *testing.T) {
+	res := bastion.Provider().ResourcesMap["wallix-bastion_connection_policy"]
+	_, err := res.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"connection_policy_name": "cp",
+		"protocol":               "RDP",
+		"type":                   "VNC",
+		"authentication_methods": []interface{}{"PASSWORD_VAULT", "PASSWORD_VAULT"},
+		"options":                `["not an object"]`,
+	}), nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	// all the problems are reported at once
+	for _, want := range []string{
+		"type VNC isn't compatible with protocol RDP",
+		"authentication_methods: PASSWORD_VAULT is defined several times",
+		"options need to be a JSON object",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error doesn't report %q: %v", want, err)
+		}
+	}
+}
+
 func testAccResourceConnectionPolicyCreate() string {
 	return `
 locals {
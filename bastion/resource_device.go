@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"slices"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -35,9 +36,16 @@ func resourceDevice() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"allow_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
 			"host": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateHost,
+				DiffSuppressFunc: suppressHostCaseDiff,
 			},
 			"alias": {
 				Type:     schema.TypeString,
@@ -129,6 +137,10 @@ func resourceDevice() *schema.Resource {
 					},
 				},
 			},
+			"href": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -138,7 +150,7 @@ func resourceDeviceVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_device not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_device", version)
 }
 
 func resourceDeviceCreate(
@@ -186,6 +198,9 @@ func resourceDeviceRead(
 		d.SetId("")
 	} else {
 		fillDevice(d, cfg)
+		if tfErr := d.Set("href", c.apiURL("/devices/"+url.PathEscape(d.Id()))); tfErr != nil {
+			panic(tfErr)
+		}
 	}
 
 	return nil
@@ -199,8 +214,10 @@ func resourceDeviceUpdate(
 	if err := resourceDeviceVersionCheck(c.bastionAPIVersion); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := updateDevice(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+	if d.HasChangesExcept("allow_destroy") {
+		if err := updateDevice(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 	d.Partial(false)
 
@@ -214,6 +231,9 @@ func resourceDeviceDelete(
 	if err := resourceDeviceVersionCheck(c.bastionAPIVersion); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := checkAllowDestroy(d, "wallix-bastion_device"); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := deleteDevice(ctx, d, m); err != nil {
 		return diag.FromErr(err)
 	}
@@ -243,6 +263,9 @@ func resourceDeviceImport(
 		return nil, err
 	}
 	fillDevice(d, cfg)
+	if tfErr := d.Set("allow_destroy", true); tfErr != nil {
+		panic(tfErr)
+	}
 	result := make([]*schema.ResourceData, 1)
 	d.SetId(id)
 	result[0] = d
@@ -256,20 +279,42 @@ func searchResourceDevice(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/devices/?q=device_name="+deviceName, http.MethodGet, nil)
+	var results []jsonDevice
+	body, code, err := c.newRequestJSON(ctx, "/devices/?q=device_name="+deviceName, http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
+	if len(results) == 1 {
+		return results[0].ID, true, nil
+	}
+
+	return "", false, nil
+}
+
+// searchResourceDeviceByAlias returns the id of the device with exactly this alias.
+func searchResourceDeviceByAlias(
+	ctx context.Context, alias string, m interface{},
+) (
+	string, bool, error,
+) {
+	c := m.(*Client)
 	var results []jsonDevice
-	err = json.Unmarshal([]byte(body), &results)
+	body, code, err := c.newRequestJSON(ctx,
+		"/devices/?"+url.Values{"q": {"alias=" + alias}}.Encode(), http.MethodGet, nil, &results)
 	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
+		return "", false, err
 	}
-	if len(results) == 1 {
-		return results[0].ID, true, nil
+	if code != http.StatusOK {
+		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
+	}
+	// the api search is a prefix match, the other devices with an alias starting with alias are ignored
+	for _, v := range results {
+		if v.Alias == alias {
+			return v.ID, true, nil
+		}
 	}
 
 	return "", false, nil
@@ -296,7 +341,8 @@ func updateDevice(
 ) error {
 	c := m.(*Client)
 	jsonData := prepareDeviceJSON(d)
-	body, code, err := c.newRequest(ctx, "/devices/"+d.Id(), http.MethodPut, jsonData)
+	priorJSONData := prepareDeviceJSON(priorResourceData(resourceDevice(), d))
+	body, code, err := c.putIfChanged(ctx, "/devices/"+d.Id(), jsonData, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -348,7 +394,7 @@ func readDeviceOptions(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
@@ -369,8 +415,20 @@ func fillDevice(d *schema.ResourceData, jsonData jsonDevice) {
 	if tfErr := d.Set("description", jsonData.Description); tfErr != nil {
 		panic(tfErr)
 	}
-	localDomains := make([]map[string]interface{}, len(*jsonData.LocalDomains))
-	for i, v := range *jsonData.LocalDomains {
+	if tfErr := d.Set("local_domains", flattenDeviceLocalDomains(jsonData.LocalDomains)); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("services", flattenDeviceServices(jsonData.Services)); tfErr != nil {
+		panic(tfErr)
+	}
+}
+
+func flattenDeviceLocalDomains(jsonLocalDomains *[]jsonDeviceLocalDomain) []map[string]interface{} {
+	if jsonLocalDomains == nil {
+		return make([]map[string]interface{}, 0)
+	}
+	localDomains := make([]map[string]interface{}, len(*jsonLocalDomains))
+	for i, v := range *jsonLocalDomains {
 		localDomains[i] = map[string]interface{}{
 			"id":                     v.ID,
 			"admin_account":          v.AdminAccount,
@@ -384,11 +442,16 @@ func fillDevice(d *schema.ResourceData, jsonData jsonDevice) {
 		pluginParameters, _ := json.Marshal(v.PasswordChangePluginParameters) //nolint: errchkjson
 		localDomains[i]["password_change_plugin_parameters"] = string(pluginParameters)
 	}
-	if tfErr := d.Set("local_domains", localDomains); tfErr != nil {
-		panic(tfErr)
+
+	return localDomains
+}
+
+func flattenDeviceServices(jsonServices *[]jsonDeviceService) []map[string]interface{} {
+	if jsonServices == nil {
+		return make([]map[string]interface{}, 0)
 	}
-	services := make([]map[string]interface{}, len(*jsonData.Services))
-	for i, v := range *jsonData.Services {
+	services := make([]map[string]interface{}, len(*jsonServices))
+	for i, v := range *jsonServices {
 		service := map[string]interface{}{
 			"id":                v.ID,
 			"service_name":      v.ServiceName,
@@ -408,7 +471,6 @@ func fillDevice(d *schema.ResourceData, jsonData jsonDevice) {
 		}
 		services[i] = service
 	}
-	if tfErr := d.Set("services", services); tfErr != nil {
-		panic(tfErr)
-	}
+
+	return services
 }
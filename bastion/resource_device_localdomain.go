@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"slices"
 	"strings"
 
@@ -37,6 +38,7 @@ func resourceDeviceLocalDomain() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceDeviceLocalDomainImport,
 		},
+		CustomizeDiff: resourceDeviceLocalDomainCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"device_id": {
 				Type:     schema.TypeString,
@@ -68,7 +70,7 @@ func resourceDeviceLocalDomain() *schema.Resource {
 			"enable_password_change": {
 				Type:         schema.TypeBool,
 				Optional:     true,
-				RequiredWith: []string{"password_change_policy", "password_change_plugin", "password_change_plugin_parameters"},
+				RequiredWith: []string{"password_change_policy", "password_change_plugin"},
 			},
 			"passphrase": {
 				Type:         schema.TypeString,
@@ -87,11 +89,20 @@ func resourceDeviceLocalDomain() *schema.Resource {
 				RequiredWith: []string{"enable_password_change"},
 			},
 			"password_change_plugin_parameters": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				RequiredWith: []string{"enable_password_change"},
-				ValidateFunc: validation.StringIsJSON,
-				Sensitive:    true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				RequiredWith:  []string{"enable_password_change"},
+				ConflictsWith: []string{"plugin_parameters"},
+				ValidateFunc:  validation.StringIsJSON,
+				Sensitive:     true,
+			},
+			"plugin_parameters": {
+				Type:          schema.TypeMap,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				RequiredWith:  []string{"enable_password_change"},
+				ConflictsWith: []string{"password_change_plugin_parameters"},
+				Sensitive:     true,
 			},
 		},
 	}
@@ -102,7 +113,24 @@ func resourceDeviceLocalDomainVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_device_localdomain not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_device_localdomain", version)
+}
+
+func resourceDeviceLocalDomainCustomizeDiff(
+	_ context.Context, d *schema.ResourceDiff, _ interface{},
+) error {
+	if err := checkPasswordChangeDiff(d); err != nil {
+		return err
+	}
+	if d.Get("enable_password_change").(bool) &&
+		d.NewValueKnown("password_change_plugin_parameters") && d.NewValueKnown("plugin_parameters") &&
+		d.Get("password_change_plugin_parameters").(string) == "" &&
+		len(d.Get("plugin_parameters").(map[string]interface{})) == 0 {
+		return errors.New("one of password_change_plugin_parameters or plugin_parameters " +
+			"need to be set with enable_password_change")
+	}
+
+	return nil
 }
 
 func resourceDeviceLocalDomainCreate(
@@ -236,21 +264,19 @@ func searchResourceDeviceLocalDomain(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/devices/"+deviceID+
-		"/localdomains/?q=domain_name="+domainName, http.MethodGet, nil)
+	var results []jsonDeviceLocalDomain
+	body, code, err := c.newRequestJSON(ctx, "/devices/"+deviceID+
+		"/localdomains/?"+url.Values{"q": {"domain_name=" + domainName}}.Encode(), http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonDeviceLocalDomain
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
-	if len(results) == 1 {
-		return results[0].ID, true, nil
+	for _, v := range results {
+		if v.DomainName == domainName {
+			return v.ID, true, nil
+		}
 	}
 
 	return "", false, nil
@@ -278,8 +304,9 @@ func updateDeviceLocalDomain(
 ) error {
 	c := m.(*Client)
 	jsonData := prepareDeviceLocalDomainJSON(d, false)
-	body, code, err := c.newRequest(ctx,
-		"/devices/"+d.Get("device_id").(string)+"/localdomains/"+d.Id(), http.MethodPut, jsonData)
+	priorJSONData := prepareDeviceLocalDomainJSON(priorResourceData(resourceDeviceLocalDomain(), d), false)
+	body, code, err := c.putIfChanged(ctx,
+		"/devices/"+d.Get("device_id").(string)+"/localdomains/"+d.Id(), jsonData, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -334,6 +361,8 @@ func prepareDeviceLocalDomainJSON(d *schema.ResourceData, newResource bool) json
 		if v := d.Get("password_change_plugin_parameters").(string); v != "" {
 			_ = json.Unmarshal([]byte(v),
 				&passChgPlug)
+		} else if v := d.Get("plugin_parameters").(map[string]interface{}); len(v) > 0 {
+			passChgPlug = v
 		} else {
 			_ = json.Unmarshal([]byte(`{}`), &passChgPlug)
 		}
@@ -360,7 +389,7 @@ func readDeviceLocalDomainOptions(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
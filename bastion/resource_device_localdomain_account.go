@@ -2,10 +2,10 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"slices"
 	"strings"
 
@@ -114,7 +114,7 @@ func resourceDeviceLocalDomainAccountVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_device_localdomain_account not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_device_localdomain_account", version)
 }
 
 func resourceDeviceLocalDomainAccountCreate(
@@ -263,21 +263,19 @@ func searchResourceDeviceLocalDomainAccount(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/devices/"+deviceID+"/localdomains/"+domainID+
-		"/accounts/?q=account_name="+accountName, http.MethodGet, nil)
+	var results []jsonDeviceLocalDomainAccount
+	body, code, err := c.newRequestJSON(ctx, "/devices/"+deviceID+"/localdomains/"+domainID+
+		"/accounts/?"+url.Values{"q": {"account_name=" + accountName}}.Encode(), http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonDeviceLocalDomainAccount
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
-	if len(results) == 1 {
-		return results[0].ID, true, nil
+	for _, v := range results {
+		if v.AccountName == accountName {
+			return v.ID, true, nil
+		}
 	}
 
 	return "", false, nil
@@ -306,9 +304,10 @@ func updateDeviceLocalDomainAccount(
 ) error {
 	c := m.(*Client)
 	jsonData := prepareDeviceLocalDomainAccountJSON(d)
-	body, code, err := c.newRequest(ctx,
+	priorJSONData := prepareDeviceLocalDomainAccountJSON(priorResourceData(resourceDeviceLocalDomainAccount(), d))
+	body, code, err := c.putIfChanged(ctx,
 		"/devices/"+d.Get("device_id").(string)+"/localdomains/"+d.Get("domain_id").(string)+
-			"/accounts/"+d.Id()+"?force=true", http.MethodPut, jsonData)
+			"/accounts/"+d.Id()+"?force=true", jsonData, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -375,7 +374,7 @@ func readDeviceLocalDomainAccountOptions(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
@@ -2,7 +2,6 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -25,18 +24,39 @@ func resourceDeviceLocalDomainAccountCredential() *schema.Resource {
 		},
 		Schema: map[string]*schema.Schema{
 			"device_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"device_id", "device_alias"},
+			},
+			"device_alias": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
 				ForceNew: true,
 			},
 			"domain_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"domain_id", "domain_name"},
+			},
+			"domain_name": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
 				ForceNew: true,
 			},
 			"account_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"account_id", "account_name"},
+			},
+			"account_name": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
 				ForceNew: true,
 			},
 			"type": {
@@ -75,8 +95,7 @@ func resourceDeviceLocalDomainAccountCredentialVersionCheck(version string) erro
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_device_localdomain_account_credential "+
-		"not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_device_localdomain_account_credential", version)
 }
 
 func resourceDeviceLocalDomainAccountCredentialCreate(
@@ -86,6 +105,9 @@ func resourceDeviceLocalDomainAccountCredentialCreate(
 	if err := resourceDeviceLocalDomainAccountCredentialVersionCheck(c.bastionAPIVersion); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := resolveDeviceLocalDomainAccountCredentialIDs(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
 	cfgDevice, err := readDeviceOptions(ctx, d.Get("device_id").(string), m)
 	if err != nil {
 		return diag.FromErr(err)
@@ -134,6 +156,14 @@ func resourceDeviceLocalDomainAccountCredentialCreate(
 			d.Get("type").(string), d.Get("account_id").(string), d.Get("domain_id").(string), d.Get("device_id").(string)))
 	}
 	d.SetId(id)
+	cfg, err := readDeviceLocalDomainAccountCredentialOptions(ctx,
+		d.Get("device_id").(string), d.Get("domain_id").(string), d.Get("account_id").(string), id, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := checkGeneratedSSHKey(d, cfg); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceDeviceLocalDomainAccountCredentialRead(ctx, d, m)
 }
@@ -167,8 +197,10 @@ func resourceDeviceLocalDomainAccountCredentialUpdate(
 	if err := resourceDeviceLocalDomainAccountCredentialVersionCheck(c.bastionAPIVersion); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := updateDeviceLocalDomainAccountCredential(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+	if !sshKeyUnchanged(d) {
+		if err := updateDeviceLocalDomainAccountCredential(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 	d.Partial(false)
 
@@ -232,26 +264,69 @@ func resourceDeviceLocalDomainAccountCredentialImport(
 	return result, nil
 }
 
+// resolveDeviceLocalDomainAccountCredentialIDs sets device_id, domain_id and account_id
+// from device_alias, domain_name and account_name when ids are not configured.
+func resolveDeviceLocalDomainAccountCredentialIDs(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	if v := d.Get("device_alias").(string); v != "" {
+		id, ex, err := searchResourceDeviceByAlias(ctx, v, m)
+		if err != nil {
+			return err
+		}
+		if !ex {
+			return fmt.Errorf("device with alias %s doesn't exists", v)
+		}
+		if tfErr := d.Set("device_id", id); tfErr != nil {
+			panic(tfErr)
+		}
+	}
+	if v := d.Get("domain_name").(string); v != "" {
+		id, ex, err := searchResourceDeviceLocalDomain(ctx, d.Get("device_id").(string), v, m)
+		if err != nil {
+			return err
+		}
+		if !ex {
+			return fmt.Errorf("domain_name %s on device_id %s doesn't exists", v, d.Get("device_id").(string))
+		}
+		if tfErr := d.Set("domain_id", id); tfErr != nil {
+			panic(tfErr)
+		}
+	}
+	if v := d.Get("account_name").(string); v != "" {
+		id, ex, err := searchResourceDeviceLocalDomainAccount(ctx,
+			d.Get("device_id").(string), d.Get("domain_id").(string), v, m)
+		if err != nil {
+			return err
+		}
+		if !ex {
+			return fmt.Errorf("account_name %s on domain_id %s, device_id %s doesn't exists",
+				v, d.Get("domain_id").(string), d.Get("device_id").(string))
+		}
+		if tfErr := d.Set("account_id", id); tfErr != nil {
+			panic(tfErr)
+		}
+	}
+
+	return nil
+}
+
 func searchResourceDeviceLocalDomainAccountCredential(
 	ctx context.Context, deviceID, domainID, accountID, typeCred string, m interface{},
 ) (
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx,
+	var results []jsonCredential
+	body, code, err := c.newRequestJSON(ctx,
 		"/devices/"+deviceID+"/localdomains/"+domainID+"/accounts/"+accountID+
-			"/credentials/", http.MethodGet, nil)
+			"/credentials/", http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonCredential
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
 	for _, v := range results {
 		if v.Type == typeCred {
 			return v.ID, true, nil
@@ -307,8 +382,12 @@ func deleteDeviceLocalDomainAccountCredential(
 	if err != nil {
 		return err
 	}
+	// already deleted outside of terraform
+	if code == http.StatusNotFound {
+		return nil
+	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return credentialDeleteError(d.Id(), code, body)
 	}
 
 	return nil
@@ -350,7 +429,7 @@ func readDeviceLocalDomainAccountCredentialOptions(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
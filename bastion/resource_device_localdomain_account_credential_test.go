@@ -1,10 +1,16 @@
 package bastion_test
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -141,3 +147,93 @@ resource "tls_private_key" "testacc_DomainAccountCred" {
 }
 `
 }
+
+func TestAccResourceDeviceLocalDomainAccountCred_names(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDeviceLocalDomainAccountCredNames(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"wallix-bastion_device_localdomain_account_credential.testacc_DeviceLocalDomainAccountCredNames",
+						"account_id",
+						"wallix-bastion_device_localdomain_account.testacc_DeviceLocalDomainAccountCredNames",
+						"id"),
+				),
+			},
+			{
+				Config:   testAccResourceDeviceLocalDomainAccountCredNames(),
+				PlanOnly: true,
+			},
+		},
+		PreventPostDestroyRefresh: true,
+	})
+}
+
+func testAccResourceDeviceLocalDomainAccountCredNames() string {
+	return `
+resource "wallix-bastion_device" "testacc_DeviceLocalDomainAccountCredNames" {
+  device_name = "testacc_DeviceLocalDomainAccountCredNames"
+  alias       = "testacc_DeviceLocalDomainAccountCredNamesAlias"
+  host        = "testacc_localdomain_account_names.device"
+}
+resource "wallix-bastion_device_localdomain" "testacc_DeviceLocalDomainAccountCredNames" {
+  device_id   = wallix-bastion_device.testacc_DeviceLocalDomainAccountCredNames.id
+  domain_name = "testacc_DeviceLocalDomainAccountCredNames"
+}
+resource "wallix-bastion_device_localdomain_account" "testacc_DeviceLocalDomainAccountCredNames" {
+  device_id     = wallix-bastion_device.testacc_DeviceLocalDomainAccountCredNames.id
+  domain_id     = wallix-bastion_device_localdomain.testacc_DeviceLocalDomainAccountCredNames.id
+  account_name  = "testacc_DeviceLocalDomainAccountCredNames_admin"
+  account_login = "admin"
+}
+resource "wallix-bastion_device_localdomain_account_credential" "testacc_DeviceLocalDomainAccountCredNames" {
+  device_alias = wallix-bastion_device.testacc_DeviceLocalDomainAccountCredNames.alias
+  domain_name  = wallix-bastion_device_localdomain.testacc_DeviceLocalDomainAccountCredNames.domain_name
+  account_name = wallix-bastion_device_localdomain_account.testacc_DeviceLocalDomainAccountCredNames.account_name
+  type         = "password"
+  password     = "aPassWord"
+}
+`
+}
+
+// TestResourceDeviceLocalDomainAccountCred_deviceAlias checks device_alias is escaped in the search
+// and resolved to the device with exactly this alias, the search of the api being a prefix match.
+func TestResourceDeviceLocalDomainAccountCred_deviceAlias(t *testing.T) {
+	devices := `[{"id":"dev-2","alias":"srv&1-backup"},{"id":"dev-1","alias":"srv&1"}]`
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/devices/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/"+bastion.VersionWallixAPI312+"/devices/" {
+			// stop the create after the resolution of the device
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+		if q := r.URL.Query().Get("q"); q != "alias=srv&1" {
+			t.Errorf("search with q = %q, want %q", q, "alias=srv&1")
+		}
+		_, _ = w.Write([]byte(devices))
+	})
+	provider := testProviderWithServer(t, mux)
+	res := provider.ResourcesMap["wallix-bastion_device_localdomain_account_credential"]
+	newData := func() *schema.ResourceData {
+		return schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+			"device_alias": "srv&1",
+			"domain_id":    "dom-id",
+			"account_id":   "acc-id",
+			"type":         "password",
+		})
+	}
+	diags := res.CreateContext(context.Background(), newData(), provider.Meta())
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "device with ID dev-1 ") {
+		t.Errorf("create: expected the device dev-1 with the exact alias, got %v", diags)
+	}
+
+	devices = `[{"id":"dev-2","alias":"srv&1-backup"}]`
+	diags = res.CreateContext(context.Background(), newData(), provider.Meta())
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "device with alias srv&1 doesn't exists") {
+		t.Errorf("create with only a device with a longer alias: expected an error, got %v", diags)
+	}
+}
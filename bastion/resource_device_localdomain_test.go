@@ -1,10 +1,17 @@
 package bastion_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"reflect"
 	"testing"
 
+	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -112,3 +119,44 @@ resource "random_password" "testacc_DeviceLocalDomain" {
 }
 `
 }
+
+func TestResourceDeviceLocalDomain_pluginParameters(t *testing.T) {
+	var posted map[string]interface{}
+	prefix := "/api/" + bastion.VersionWallixAPI312 + "/devices/dev"
+	provider := testProviderWithServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&posted)
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == prefix:
+			_, _ = w.Write([]byte(`{"id":"dev","device_name":"dev","host":"host.none"}`))
+		case r.URL.Path == prefix+"/localdomains/" && posted == nil:
+			_, _ = w.Write([]byte(`[]`))
+		case r.URL.Path == prefix+"/localdomains/":
+			_, _ = w.Write([]byte(`[{"id":"dom","domain_name":"domain"}]`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"dom","domain_name":"domain","enable_password_change":true,` +
+				`"password_change_policy":"default","password_change_plugin":"Unix"}`))
+		}
+	}))
+	res := provider.ResourcesMap["wallix-bastion_device_localdomain"]
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"device_id":              "dev",
+		"domain_name":            "domain",
+		"enable_password_change": true,
+		"password_change_policy": "default",
+		"password_change_plugin": "Unix",
+		"plugin_parameters": map[string]interface{}{
+			"host": "host.none",
+			"port": "22",
+		},
+	})
+	if diags := res.CreateContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	// the values of the map are sent as strings, even the ones looking like numbers
+	want := map[string]interface{}{"host": "host.none", "port": "22"}
+	if got := posted["password_change_plugin_parameters"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("password_change_plugin_parameters posted = %#v, want %#v", got, want)
+	}
+}
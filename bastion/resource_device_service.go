@@ -2,7 +2,6 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -82,7 +81,7 @@ func resourceDeviceServiceVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_device_service not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_device_service", version)
 }
 
 func resourceDeviceServiceCreate(
@@ -216,19 +215,15 @@ func searchResourceDeviceService(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/devices/"+deviceID+
-		"/services/?q=service_name="+serviceName, http.MethodGet, nil)
+	var results []jsonDeviceService
+	body, code, err := c.newRequestJSON(ctx, "/devices/"+deviceID+
+		"/services/?q=service_name="+serviceName, http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonDeviceService
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
 	if len(results) == 1 {
 		return results[0].ID, true, nil
 	}
@@ -263,8 +258,12 @@ func updateDeviceService(
 	if err != nil {
 		return err
 	}
-	body, code, err := c.newRequest(ctx,
-		"/devices/"+d.Get("device_id").(string)+"/services/"+d.Id()+"?force=true", http.MethodPut, json)
+	var priorJSONData interface{}
+	if v, err := prepareDeviceServiceJSON(priorResourceData(resourceDeviceService(), d), false); err == nil {
+		priorJSONData = v
+	}
+	body, code, err := c.putIfChanged(ctx,
+		"/devices/"+d.Get("device_id").(string)+"/services/"+d.Id()+"?force=true", json, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -386,7 +385,7 @@ func readDeviceServiceOptions(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
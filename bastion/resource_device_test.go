@@ -3,6 +3,8 @@ package bastion_test
 import (
 	"testing"
 
+	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -40,6 +42,26 @@ func TestAccResourceDevice_basic(t *testing.T) {
 	})
 }
 
+func TestResourceDevice_host(t *testing.T) {
+	host := bastion.Provider().ResourcesMap["wallix-bastion_device"].Schema["host"]
+	for _, v := range []string{"server1", "Server1.Domain.Local", "testacc_App", "192.0.2.1", "2001:db8::1"} {
+		if _, errs := host.ValidateFunc(v, "host"); len(errs) > 0 {
+			t.Errorf("host %q refused: %v", v, errs)
+		}
+	}
+	for _, v := range []string{"", "server 1", "server1:22", "-server1", "https://server1", "server1..local"} {
+		if _, errs := host.ValidateFunc(v, "host"); len(errs) == 0 {
+			t.Errorf("host %q accepted", v)
+		}
+	}
+	if !host.DiffSuppressFunc("host", "server1.domain.local", "Server1.Domain.Local", nil) {
+		t.Error("change of case of host not suppressed")
+	}
+	if host.DiffSuppressFunc("host", "server1", "server2", nil) {
+		t.Error("change of host suppressed")
+	}
+}
+
 func testAccResourceDeviceCreate() string {
 	return `
 resource "wallix-bastion_device" "testacc_Device" {
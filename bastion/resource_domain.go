@@ -3,8 +3,10 @@ package bastion
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"slices"
 	"strings"
 
@@ -39,11 +41,17 @@ func resourceDomain() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceDomainImport,
 		},
+		CustomizeDiff: resourceDomainCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"domain_name": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"allow_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
 			"domain_real_name": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -63,6 +71,11 @@ func resourceDomain() *schema.Resource {
 				Sensitive:     true,
 				ConflictsWith: []string{"vault_plugin"},
 			},
+			"ca_key_rotation": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"ca_private_key"},
+			},
 			"description": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -110,6 +123,10 @@ func resourceDomain() *schema.Resource {
 				ValidateFunc: validation.StringIsJSON,
 				Sensitive:    true,
 			},
+			"href": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -119,7 +136,31 @@ func resourceDomainVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_domain not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_domain", version)
+}
+
+func resourceDomainCustomizeDiff(
+	_ context.Context, d *schema.ResourceDiff, _ interface{},
+) error {
+	var errs []error
+	if err := checkPasswordChangeDiff(d); err != nil {
+		errs = append(errs, err)
+	}
+	if d.Get("ca_key_rotation").(string) != "" && d.NewValueKnown("ca_private_key") &&
+		!strings.HasPrefix(d.Get("ca_private_key").(string), "generate:") {
+		errs = append(errs, errors.New("ca_key_rotation can only be used with a ca_private_key "+
+			"generated by the Bastion (generate:<type>)"))
+	}
+	// a new value of ca_key_rotation regenerates the CA key, so the public key changes
+	// (removing the argument doesn't regenerate it)
+	if d.Id() != "" && d.HasChange("ca_key_rotation") && d.Get("ca_key_rotation").(string) != "" &&
+		strings.HasPrefix(d.Get("ca_private_key").(string), "generate:") {
+		if err := d.SetNewComputed("ca_public_key"); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
 func resourceDomainCreate(
@@ -167,6 +208,9 @@ func resourceDomainRead(
 		d.SetId("")
 	} else {
 		fillDomain(d, cfg)
+		if tfErr := d.Set("href", c.apiURL("/domains/"+url.PathEscape(d.Id()))); tfErr != nil {
+			panic(tfErr)
+		}
 	}
 
 	return nil
@@ -180,8 +224,10 @@ func resourceDomainUpdate(
 	if err := resourceDomainVersionCheck(c.bastionAPIVersion); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := updateDomain(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+	if d.HasChangesExcept("allow_destroy") {
+		if err := updateDomain(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 	d.Partial(false)
 
@@ -195,6 +241,9 @@ func resourceDomainDelete(
 	if err := resourceDomainVersionCheck(c.bastionAPIVersion); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := checkAllowDestroy(d, "wallix-bastion_domain"); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := deleteDomain(ctx, d, m); err != nil {
 		return diag.FromErr(err)
 	}
@@ -224,6 +273,9 @@ func resourceDomainImport(
 		return nil, err
 	}
 	fillDomain(d, cfg)
+	if tfErr := d.Set("allow_destroy", true); tfErr != nil {
+		panic(tfErr)
+	}
 	result := make([]*schema.ResourceData, 1)
 	d.SetId(id)
 	result[0] = d
@@ -237,18 +289,14 @@ func searchResourceDomain(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/domains/?q=domain_name="+domainName, http.MethodGet, nil)
+	var results []jsonDomain
+	body, code, err := c.newRequestJSON(ctx, "/domains/?q=domain_name="+domainName, http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonDomain
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
 	if len(results) == 1 {
 		return results[0].ID, true, nil
 	}
@@ -277,7 +325,8 @@ func updateDomain(
 ) error {
 	c := m.(*Client)
 	jsonData := prepareDomainJSON(d, false)
-	body, code, err := c.newRequest(ctx, "/domains/"+d.Id(), http.MethodPut, jsonData)
+	priorJSONData := prepareDomainJSON(priorResourceData(resourceDomain(), d), false)
+	body, code, err := c.putIfChanged(ctx, "/domains/"+d.Id(), jsonData, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -313,6 +362,9 @@ func prepareDomainJSON(d *schema.ResourceData, newResource bool) jsonDomain {
 
 	if !strings.HasPrefix(d.Get("ca_private_key").(string), "generate:") {
 		jsonData.CAPrivateKey = d.Get("ca_private_key").(string)
+	} else if !newResource && d.HasChange("ca_key_rotation") && d.Get("ca_key_rotation").(string) != "" {
+		// send the generate value again to rotate the CA key
+		jsonData.CAPrivateKey = d.Get("ca_private_key").(string)
 	} else if d.HasChange("ca_private_key") {
 		oldKey, newKey := d.GetChange("ca_private_key")
 		if oldKey.(string) == "" {
@@ -368,7 +420,7 @@ func readDomainOptions(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
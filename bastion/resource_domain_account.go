@@ -2,7 +2,6 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -110,7 +109,7 @@ func resourceDomainAccountVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_domain_account not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_domain_account", version)
 }
 
 func resourceDomainAccountCreate(
@@ -245,19 +244,15 @@ func searchResourceDomainAccount(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx,
-		"/domains/"+domainID+"/accounts/?q=account_name="+accountName, http.MethodGet, nil)
+	var results []jsonDomainAccount
+	body, code, err := c.newRequestJSON(ctx,
+		"/domains/"+domainID+"/accounts/?q=account_name="+accountName, http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonDomainAccount
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
 	if len(results) == 1 {
 		return results[0].ID, true, nil
 	}
@@ -293,8 +288,12 @@ func updateDomainAccount(
 	if err != nil {
 		return err
 	}
-	body, code, err := c.newRequest(ctx,
-		"/domains/"+d.Get("domain_id").(string)+"/accounts/"+d.Id()+"?force=true", http.MethodPut, jsonData)
+	var priorJSONData interface{}
+	if v, err := prepareDomainAccountJSON(priorResourceData(resourceDomainAccount(), d)); err == nil {
+		priorJSONData = v
+	}
+	body, code, err := c.putIfChanged(ctx,
+		"/domains/"+d.Get("domain_id").(string)+"/accounts/"+d.Id()+"?force=true", jsonData, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -365,7 +364,7 @@ func readDomainAccountOptions(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
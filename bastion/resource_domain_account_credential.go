@@ -2,7 +2,6 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -74,7 +73,7 @@ func resourceDomainAccountCredentialVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_domain_account_credential not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_domain_account_credential", version)
 }
 
 func resourceDomainAccountCredentialCreate(
@@ -123,6 +122,14 @@ func resourceDomainAccountCredentialCreate(
 			d.Get("type").(string), d.Get("account_id").(string), d.Get("domain_id").(string)))
 	}
 	d.SetId(id)
+	cfg, err := readDomainAccountCredentialOptions(ctx,
+		d.Get("domain_id").(string), d.Get("account_id").(string), id, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := checkGeneratedSSHKey(d, cfg); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceDomainAccountCredentialRead(ctx, d, m)
 }
@@ -156,8 +163,11 @@ func resourceDomainAccountCredentialUpdate(
 	if err := resourceDomainAccountCredentialVersionCheck(c.bastionAPIVersion); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := updateDomainAccountCredential(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+	// with propagate_credential_change, the password is sent for all the types
+	if d.Get("propagate_credential_change").(bool) || !sshKeyUnchanged(d) {
+		if err := updateDomainAccountCredential(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 	d.Partial(false)
 
@@ -224,20 +234,16 @@ func searchResourceDomainAccountCredential(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx,
+	var results []jsonCredential
+	body, code, err := c.newRequestJSON(ctx,
 		"/domains/"+domainID+"/accounts/"+accountID+
-			"/credentials/", http.MethodGet, nil)
+			"/credentials/", http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonCredential
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
 	for _, v := range results {
 		if v.Type == typeCred {
 			return v.ID, true, nil
@@ -338,7 +344,7 @@ func deleteDomainAccountCredential(
 		return err
 	}
 	if code != http.StatusOK && code != http.StatusNoContent {
-		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
+		return credentialDeleteError(d.Id(), code, body)
 	}
 
 	return nil
@@ -391,7 +397,7 @@ func readDomainAccountCredentialOptions(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
@@ -0,0 +1,253 @@
+package bastion
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceDomainAccountsAutoChange() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDomainAccountsAutoChangeCreate,
+		ReadContext:   resourceDomainAccountsAutoChangeRead,
+		UpdateContext: resourceDomainAccountsAutoChangeUpdate,
+		DeleteContext: resourceDomainAccountsAutoChangeDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDomainAccountsAutoChangeImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"auto_change_password": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"auto_change_ssh_key": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"account_names": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceDomainAccountsAutoChangeVersionCheck(version string) error {
+	if slices.Contains(defaultVersionsValid(), version) {
+		return nil
+	}
+
+	return versionNotAvailableError("resource", "wallix-bastion_domain_accounts_auto_change", version)
+}
+
+func resourceDomainAccountsAutoChangeCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceDomainAccountsAutoChangeVersionCheck(c.bastionAPIVersion); err != nil {
+		return diag.FromErr(err)
+	}
+	cfgDomain, err := readDomainOptions(ctx, d.Get("domain_id").(string), m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if cfgDomain.ID == "" {
+		return diag.FromErr(fmt.Errorf("domain with ID %s doesn't exists", d.Get("domain_id").(string)))
+	}
+	if err := updateDomainAccountsAutoChange(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(d.Get("domain_id").(string))
+
+	return resourceDomainAccountsAutoChangeRead(ctx, d, m)
+}
+
+func resourceDomainAccountsAutoChangeRead(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceDomainAccountsAutoChangeVersionCheck(c.bastionAPIVersion); err != nil {
+		return diag.FromErr(err)
+	}
+	cfgDomain, err := readDomainOptions(ctx, d.Id(), m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if cfgDomain.ID == "" {
+		d.SetId("")
+
+		return nil
+	}
+	accounts, err := listDomainAccounts(ctx, d.Id(), m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	fillDomainAccountsAutoChange(d, accounts)
+
+	return nil
+}
+
+func resourceDomainAccountsAutoChangeUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceDomainAccountsAutoChangeVersionCheck(c.bastionAPIVersion); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := updateDomainAccountsAutoChange(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceDomainAccountsAutoChangeRead(ctx, d, m)
+}
+
+// resourceDomainAccountsAutoChangeDelete only removes the resource from the state,
+// the accounts keep their last auto change values.
+func resourceDomainAccountsAutoChangeDelete(
+	_ context.Context, _ *schema.ResourceData, m interface{},
+) diag.Diagnostics {
+	c := m.(*Client)
+	if err := resourceDomainAccountsAutoChangeVersionCheck(c.bastionAPIVersion); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceDomainAccountsAutoChangeImport(
+	d *schema.ResourceData, m interface{},
+) (
+	[]*schema.ResourceData, error,
+) {
+	ctx := context.Background()
+	c := m.(*Client)
+	if err := resourceDomainAccountsAutoChangeVersionCheck(c.bastionAPIVersion); err != nil {
+		return nil, err
+	}
+	id, ex, err := searchResourceDomain(ctx, d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+	if !ex {
+		return nil, fmt.Errorf("don't find domain_name with id %s (id must be <domain_name>)", d.Id())
+	}
+	accounts, err := listDomainAccounts(ctx, id, m)
+	if err != nil {
+		return nil, err
+	}
+	if tfErr := d.Set("domain_id", id); tfErr != nil {
+		panic(tfErr)
+	}
+	// the values shared by all the accounts, false when they differ
+	for _, k := range []string{"auto_change_password", "auto_change_ssh_key"} {
+		if tfErr := d.Set(k, true); tfErr != nil {
+			panic(tfErr)
+		}
+	}
+	fillDomainAccountsAutoChange(d, accounts)
+	result := make([]*schema.ResourceData, 1)
+	d.SetId(id)
+	result[0] = d
+
+	return result, nil
+}
+
+func listDomainAccounts(
+	ctx context.Context, domainID string, m interface{},
+) (
+	[]jsonDomainAccount, error,
+) {
+	c := m.(*Client)
+	objects, err := c.listAll(ctx, "/domains/"+domainID+"/accounts/", nil)
+	if err != nil {
+		return nil, err
+	}
+	accounts := make([]jsonDomainAccount, len(objects))
+	for i, v := range objects {
+		if err := c.unmarshalJSON(ctx, string(v), &accounts[i]); err != nil {
+			return nil, fmt.Errorf("unmarshaling json: %w", err)
+		}
+	}
+
+	return accounts, nil
+}
+
+// updateDomainAccountsAutoChange puts each account of the domain with different auto change values,
+// with only these values changed.
+func updateDomainAccountsAutoChange(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	c := m.(*Client)
+	domainID := d.Get("domain_id").(string)
+	autoChangePassword := d.Get("auto_change_password").(bool)
+	autoChangeSSHKey := d.Get("auto_change_ssh_key").(bool)
+	accounts, err := listDomainAccounts(ctx, domainID, m)
+	if err != nil {
+		return err
+	}
+	for _, account := range accounts {
+		if account.AutoChangePassword == autoChangePassword && account.AutoChangeSSHKey == autoChangeSSHKey {
+			continue
+		}
+		accountID := account.ID
+		// the id, computed fields and lists of the account aren't sent to not change them
+		account.ID = ""
+		account.DomainPasswordChange = nil
+		account.Resources = nil
+		account.Credentials = nil
+		account.AutoChangePassword = autoChangePassword
+		account.AutoChangeSSHKey = autoChangeSSHKey
+		body, code, err := c.newRequest(ctx,
+			"/domains/"+domainID+"/accounts/"+accountID+"?force=true", http.MethodPut, account)
+		if err != nil {
+			return err
+		}
+		if code != http.StatusOK && code != http.StatusNoContent {
+			return fmt.Errorf("api doesn't return OK or NoContent on account %s: %d with body:\n%s",
+				account.AccountName, code, body)
+		}
+	}
+
+	return nil
+}
+
+// fillDomainAccountsAutoChange sets the account names and keeps the auto change values of the state
+// only when all the accounts have them, so an account changed outside of Terraform produces a diff.
+func fillDomainAccountsAutoChange(d *schema.ResourceData, accounts []jsonDomainAccount) {
+	autoChangePassword := d.Get("auto_change_password").(bool)
+	autoChangeSSHKey := d.Get("auto_change_ssh_key").(bool)
+	accountNames := make([]string, len(accounts))
+	for i, v := range accounts {
+		accountNames[i] = v.AccountName
+		if v.AutoChangePassword != d.Get("auto_change_password").(bool) {
+			autoChangePassword = v.AutoChangePassword
+		}
+		if v.AutoChangeSSHKey != d.Get("auto_change_ssh_key").(bool) {
+			autoChangeSSHKey = v.AutoChangeSSHKey
+		}
+	}
+	if tfErr := d.Set("account_names", accountNames); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("auto_change_password", autoChangePassword); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("auto_change_ssh_key", autoChangeSSHKey); tfErr != nil {
+		panic(tfErr)
+	}
+}
@@ -0,0 +1,83 @@
+package bastion_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestResourceDomainAccountsAutoChange_basic checks only the accounts with different values are updated.
+func TestResourceDomainAccountsAutoChange_basic(t *testing.T) {
+	accounts := map[string]map[string]interface{}{
+		"acc1": {"id": "acc1", "account_name": "admin", "account_login": "admin", "auto_change_password": true},
+		"acc2": {"id": "acc2", "account_name": "svc", "account_login": "svc", "auto_change_password": false},
+		"acc3": {
+			"id": "acc3", "account_name": "backup", "account_login": "backup",
+			"auto_change_password": false, "auto_change_ssh_key": true,
+		},
+	}
+	var updated []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/domains/dom", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"dom","domain_name":"corp"}`))
+	})
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/domains/dom/accounts/", func(w http.ResponseWriter, r *http.Request) {
+		accountID := strings.TrimPrefix(r.URL.Path, "/api/"+bastion.VersionWallixAPI312+"/domains/dom/accounts/")
+		if r.Method == http.MethodPut {
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := body["id"]; ok {
+				t.Errorf("id sent on update: %v", body)
+			}
+			if body["account_login"] != accounts[accountID]["account_login"] {
+				t.Errorf("other arguments of the account not kept: %v", body)
+			}
+			accounts[accountID] = body
+			accounts[accountID]["id"] = accountID
+			updated = append(updated, accountID)
+			w.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+		list := []interface{}{accounts["acc1"], accounts["acc2"], accounts["acc3"]}
+		if r.URL.Query().Get("offset") != "0" {
+			list = nil
+		}
+		_ = json.NewEncoder(w).Encode(list)
+	})
+	provider := testProviderWithServer(t, mux)
+	res := provider.ResourcesMap["wallix-bastion_domain_accounts_auto_change"]
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"domain_id":            "dom",
+		"auto_change_password": true,
+	})
+	if diags := res.CreateContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if strings.Join(updated, ",") != "acc2,acc3" {
+		t.Errorf("updated accounts = %v, want [acc2 acc3]", updated)
+	}
+	if accounts["acc3"]["auto_change_ssh_key"] != false || accounts["acc2"]["auto_change_password"] != true {
+		t.Errorf("accounts not updated with the values: %v", accounts)
+	}
+	if v := d.Get("account_names").(*schema.Set); v.Len() != 3 {
+		t.Errorf("account_names = %v, want 3 names", v.List())
+	}
+
+	// an account changed outside of Terraform produces a diff
+	accounts["acc1"]["auto_change_password"] = false
+	if diags := res.ReadContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Get("auto_change_password").(bool) {
+		t.Error("auto_change_password = true with an account without it, want false")
+	}
+}
@@ -2,7 +2,6 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"slices"
@@ -43,7 +42,7 @@ func resourceEncryptionVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_encryption is not available with API version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_encryption", version)
 }
 
 func resourceEncryptionCreate(
@@ -184,7 +183,7 @@ func verifyEncryption(
 
 	// Check if encryption exists
 	var result map[string]interface{}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return false, fmt.Errorf("unmarshaling JSON: %w", err)
 	}
@@ -2,7 +2,7 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
+	"encoding/base64"
 	"fmt"
 	"net/http"
 	"slices"
@@ -13,15 +13,15 @@ import (
 )
 
 type jsonExternalAuthKerberos struct {
-	UsePrimaryAuthDomain bool   `json:"use_primary_auth_domain"`
-	Port                 int    `json:"port"`
-	ID                   string `json:"id,omitempty"`
-	AuthenticationName   string `json:"authentication_name"`
-	Description          string `json:"description"`
-	Host                 string `json:"host"`
-	KerDomController     string `json:"ker_dom_controller"`
-	KeyTab               string `json:"keytab,omitempty"`
-	Type                 string `json:"type"`
+	UsePrimaryAuthDomain jsonBool `json:"use_primary_auth_domain"`
+	Port                 jsonInt  `json:"port"`
+	ID                   string   `json:"id,omitempty"`
+	AuthenticationName   string   `json:"authentication_name"`
+	Description          string   `json:"description"`
+	Host                 string   `json:"host"`
+	KerDomController     string   `json:"ker_dom_controller"`
+	KeyTab               string   `json:"keytab,omitempty"`
+	Type                 string   `json:"type"`
 }
 
 func resourceExternalAuthKerberos() *schema.Resource {
@@ -37,10 +37,13 @@ func resourceExternalAuthKerberos() *schema.Resource {
 			"authentication_name": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"host": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateHost,
+				DiffSuppressFunc: suppressHostCaseDiff,
 			},
 			"ker_dom_controller": {
 				Type:     schema.TypeString,
@@ -61,9 +64,10 @@ func resourceExternalAuthKerberos() *schema.Resource {
 				Optional: true,
 			},
 			"keytab": {
-				Type:      schema.TypeString,
-				Optional:  true,
-				Sensitive: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ValidateFunc: validateKeytab,
 			},
 			"login_attribute": {
 				Type:     schema.TypeString,
@@ -79,12 +83,25 @@ func resourceExternalAuthKerberos() *schema.Resource {
 	}
 }
 
+// validateKeytab checks that a keytab is base64 encoded, without the value in the error as it's sensitive.
+func validateKeytab(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if _, err := base64.StdEncoding.DecodeString(v); err != nil {
+		return nil, []error{fmt.Errorf("%s need to be base64 encoded (with the filebase64 function): %w", k, err)}
+	}
+
+	return nil, nil
+}
+
 func resourceExternalAuthKerberosVersionCheck(version string) error {
 	if slices.Contains(defaultVersionsValid(), version) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_externalauth_kerberos not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_externalauth_kerberos", version)
 }
 
 func resourceExternalAuthKerberosCreate(
@@ -202,20 +219,21 @@ func searchResourceExternalAuthKerberos(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/externalauths/?q=authentication_name="+authenticationName, http.MethodGet, nil)
+	var results []jsonExternalAuthKerberos
+	body, code, err := c.newRequestJSON(ctx,
+		"/externalauths/?q=authentication_name="+authenticationName, http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonExternalAuthKerberos
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
-	if len(results) == 1 {
-		return results[0].ID, true, nil
+	// the search can also return other external authentications with a related name or of another type
+	for _, v := range results {
+		if v.AuthenticationName == authenticationName &&
+			(v.Type == "KERBEROS" || v.Type == "KERBEROS-PASSWORD") {
+			return v.ID, true, nil
+		}
 	}
 
 	return "", false, nil
@@ -242,7 +260,8 @@ func updateExternalAuthKerberos(
 ) error {
 	c := m.(*Client)
 	jsonData := prepareExternalAuthKerberosJSON(d)
-	body, code, err := c.newRequest(ctx, "/externalauths/"+d.Id(), http.MethodPut, jsonData)
+	priorJSONData := prepareExternalAuthKerberosJSON(priorResourceData(resourceExternalAuthKerberos(), d))
+	body, code, err := c.putIfChanged(ctx, "/externalauths/"+d.Id(), jsonData, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -273,10 +292,10 @@ func prepareExternalAuthKerberosJSON(d *schema.ResourceData) jsonExternalAuthKer
 		AuthenticationName:   d.Get("authentication_name").(string),
 		Host:                 d.Get("host").(string),
 		KerDomController:     d.Get("ker_dom_controller").(string),
-		Port:                 d.Get("port").(int),
+		Port:                 jsonInt(d.Get("port").(int)),
 		Description:          d.Get("description").(string),
 		KeyTab:               d.Get("keytab").(string),
-		UsePrimaryAuthDomain: d.Get("use_primary_auth_domain").(bool),
+		UsePrimaryAuthDomain: jsonBool(d.Get("use_primary_auth_domain").(bool)),
 		Type:                 "KERBEROS",
 	}
 	if d.Get("kerberos_password").(bool) {
@@ -304,7 +323,7 @@ func readExternalAuthKerberosOptions(
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
 
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
@@ -322,13 +341,13 @@ func fillExternalAuthKerberos(d *schema.ResourceData, jsonData jsonExternalAuthK
 	if tfErr := d.Set("ker_dom_controller", jsonData.KerDomController); tfErr != nil {
 		panic(tfErr)
 	}
-	if tfErr := d.Set("port", jsonData.Port); tfErr != nil {
+	if tfErr := d.Set("port", int(jsonData.Port)); tfErr != nil {
 		panic(tfErr)
 	}
 	if tfErr := d.Set("description", jsonData.Description); tfErr != nil {
 		panic(tfErr)
 	}
-	if tfErr := d.Set("use_primary_auth_domain", jsonData.UsePrimaryAuthDomain); tfErr != nil {
+	if tfErr := d.Set("use_primary_auth_domain", bool(jsonData.UsePrimaryAuthDomain)); tfErr != nil {
 		panic(tfErr)
 	}
 	if jsonData.Type == "KERBEROS-PASSWORD" {
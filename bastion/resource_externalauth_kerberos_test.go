@@ -2,10 +2,15 @@
 package bastion_test
 
 import (
+	"encoding/base64"
 	"encoding/hex"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -13,6 +18,51 @@ const (
 	keytabDataHexStr = "0502000000320001000b4558414d504c452e434f4d00047573657200000001586aa82d01001700100c61039f010b2fbb88fe449fbf262477000000420001000b4558414d504c452e434f4d00047573657200000001586aa82d010012002053142f614ee6c39823710d9f31ff2984ed0bd9074d6e542e8468137f7b909c17000000320001000b4558414d504c452e434f4d00047573657200000001586beaad01001700100c61039f010b2fbb88fe449fbf262477000000420001000b4558414d504c452e434f4d00047573657200000001586beaae010012002053142f614ee6c39823710d9f31ff2984ed0bd9074d6e542e8468137f7b909c17000000430001000b4a544c414e2e434f2e554b000562696c6c7900000001586beaae1f00120020508dd2b209064e101bf209caef5fda236875706a5e9ad47c157db5907778785f" //nolint: lll
 )
 
+func TestResourceExternalAuthKerberos_keytab(t *testing.T) {
+	validate := bastion.Provider().ResourcesMap["wallix-bastion_externalauth_kerberos"].Schema["keytab"].ValidateFunc
+	k, _ := hex.DecodeString(keytabDataHexStr)
+	if _, errs := validate(base64.StdEncoding.EncodeToString(k), "keytab"); len(errs) > 0 {
+		t.Errorf("unexpected errors with a base64 keytab: %v", errs)
+	}
+	_, errs := validate(string(k), "keytab")
+	if len(errs) == 0 {
+		t.Fatal("expected an error with a keytab not base64 encoded")
+	}
+	if strings.Contains(errs[0].Error(), "EXAMPLE.COM") {
+		t.Errorf("the keytab is in the error: %v", errs[0])
+	}
+}
+
+// TestResourceExternalAuthKerberos_importType checks that only a Kerberos external authentication
+// with the exact name is imported.
+func TestResourceExternalAuthKerberos_importType(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/externalauths/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/" + bastion.VersionWallixAPI312 + "/externalauths/":
+			_, _ = w.Write([]byte(`[{"id":"1","authentication_name":"realm","type":"LDAP"},` +
+				`{"id":"2","authentication_name":"realm","type":"KERBEROS-PASSWORD"}]`))
+		case "/api/" + bastion.VersionWallixAPI312 + "/externalauths/2":
+			_, _ = w.Write([]byte(`{"id":"2","authentication_name":"realm","type":"KERBEROS-PASSWORD",` +
+				`"host":"kdc.local","port":88,"ker_dom_controller":"EXAMPLE.COM"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	provider := testProviderWithServer(t, mux)
+	res := provider.ResourcesMap["wallix-bastion_externalauth_kerberos"]
+	d := res.Data(nil)
+	d.SetId("realm")
+	result, err := res.Importer.State(d, provider.Meta())
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if result[0].Id() != "2" || !result[0].Get("kerberos_password").(bool) {
+		t.Errorf("imported id = %q, kerberos_password = %v, want 2 and true",
+			result[0].Id(), result[0].Get("kerberos_password"))
+	}
+}
+
 func TestAccResourceExternalAuthKerberos_basic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
@@ -2,10 +2,11 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"slices"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -13,28 +14,28 @@ import (
 )
 
 type jsonExternalAuthLdap struct {
-	IsActiveDirectory    bool    `json:"is_active_directory"`
-	IsAnonymousAccess    bool    `json:"is_anonymous_access"`
-	IsProtectedUser      bool    `json:"is_protected_user"`
-	IsSSL                bool    `json:"is_ssl"`
-	IsStartTLS           bool    `json:"is_starttls"`
-	UsePrimaryAuthDomain bool    `json:"use_primary_auth_domain"`
-	Port                 int     `json:"port"`
-	Timeout              float64 `json:"timeout"`
-	ID                   string  `json:"id,omitempty"`
-	AuthenticationName   string  `json:"authentication_name"`
-	CACertificate        string  `json:"ca_certificate"`
-	Certificate          string  `json:"certificate"`
-	CNAttribute          string  `json:"cn_attribute"`
-	Description          string  `json:"description"`
-	LDAPBase             string  `json:"ldap_base"`
-	Login                string  `json:"login,omitempty"`
-	LoginAttribute       string  `json:"login_attribute"`
-	Host                 string  `json:"host"`
-	Passphrase           string  `json:"passphrase,omitempty"`
-	Password             string  `json:"password,omitempty"`
-	PrivateKey           string  `json:"private_key"`
-	Type                 string  `json:"type"`
+	IsActiveDirectory    jsonBool `json:"is_active_directory"`
+	IsAnonymousAccess    jsonBool `json:"is_anonymous_access"`
+	IsProtectedUser      jsonBool `json:"is_protected_user"`
+	IsSSL                jsonBool `json:"is_ssl"`
+	IsStartTLS           jsonBool `json:"is_starttls"`
+	UsePrimaryAuthDomain jsonBool `json:"use_primary_auth_domain"`
+	Port                 jsonInt  `json:"port"`
+	Timeout              float64  `json:"timeout"`
+	ID                   string   `json:"id,omitempty"`
+	AuthenticationName   string   `json:"authentication_name"`
+	CACertificate        string   `json:"ca_certificate"`
+	Certificate          string   `json:"certificate"`
+	CNAttribute          string   `json:"cn_attribute"`
+	Description          string   `json:"description"`
+	LDAPBase             string   `json:"ldap_base"`
+	Login                string   `json:"login,omitempty"`
+	LoginAttribute       string   `json:"login_attribute"`
+	Host                 string   `json:"host"`
+	Passphrase           string   `json:"passphrase,omitempty"`
+	Password             string   `json:"password,omitempty"`
+	PrivateKey           string   `json:"private_key"`
+	Type                 string   `json:"type"`
 }
 
 func resourceExternalAuthLdap() *schema.Resource {
@@ -46,6 +47,7 @@ func resourceExternalAuthLdap() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceExternalAuthLdapImport,
 		},
+		CustomizeDiff: resourceExternalAuthLdapCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"authentication_name": {
 				Type:     schema.TypeString,
@@ -53,11 +55,14 @@ func resourceExternalAuthLdap() *schema.Resource {
 			},
 			"cn_attribute": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"host": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateHost,
+				DiffSuppressFunc: suppressHostCaseDiff,
 			},
 			"ldap_base": {
 				Type:     schema.TypeString,
@@ -65,7 +70,8 @@ func resourceExternalAuthLdap() *schema.Resource {
 			},
 			"login_attribute": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"port": {
 				Type:         schema.TypeInt,
@@ -142,7 +148,55 @@ func resourceExternalAuthLdapVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_externalauth_ldap not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_externalauth_ldap", version)
+}
+
+func resourceExternalAuthLdapCustomizeDiff(
+	_ context.Context, d *schema.ResourceDiff, _ interface{},
+) error {
+	if d.Get("is_ssl").(bool) && d.Get("is_starttls").(bool) {
+		return errors.New("is_ssl (LDAPS) and is_starttls (STARTTLS) can't be both true")
+	}
+	if d.Get("is_anonymous_access").(bool) {
+		var bindArgs []string
+		for _, k := range []string{"login", "password", "private_key"} {
+			if d.Get(k).(string) != "" {
+				bindArgs = append(bindArgs, k)
+			}
+		}
+		if len(bindArgs) > 0 {
+			return fmt.Errorf("%s can't be set with is_anonymous_access = true", strings.Join(bindArgs, ", "))
+		}
+	}
+	if !d.NewValueKnown("is_active_directory") {
+		return nil
+	}
+	// default attributes depend on the directory type when not set
+	defaults := map[string]string{
+		"cn_attribute":    "cn",
+		"login_attribute": "uid",
+	}
+	if d.Get("is_active_directory").(bool) {
+		defaults = map[string]string{
+			"cn_attribute":    "sAMAccountName",
+			"login_attribute": "sAMAccountName",
+		}
+	}
+	rawConfig := d.GetRawConfig()
+	for k, v := range defaults {
+		if rawConfig.IsKnown() && !rawConfig.IsNull() {
+			if !rawConfig.GetAttr(k).IsNull() {
+				continue
+			}
+		} else if d.Get(k).(string) != "" {
+			continue
+		}
+		if err := d.SetNew(k, v); err != nil {
+			return fmt.Errorf("setting default %s: %w", k, err)
+		}
+	}
+
+	return nil
 }
 
 func resourceExternalAuthLdapCreate(
@@ -268,18 +322,15 @@ func searchResourceExternalAuthLdap(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/externalauths/?q=authentication_name="+authenticationName, http.MethodGet, nil)
+	var results []jsonExternalAuthLdap
+	body, code, err := c.newRequestJSON(ctx,
+		"/externalauths/?q=authentication_name="+authenticationName, http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonExternalAuthLdap
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
 	if len(results) == 1 {
 		return results[0].ID, true, nil
 	}
@@ -308,7 +359,8 @@ func updateExternalAuthLdap(
 ) error {
 	c := m.(*Client)
 	jsonData := prepareExternalAuthLdapJSON(d)
-	body, code, err := c.newRequest(ctx, "/externalauths/"+d.Id(), http.MethodPut, jsonData)
+	priorJSONData := prepareExternalAuthLdapJSON(priorResourceData(resourceExternalAuthLdap(), d))
+	body, code, err := c.putIfChanged(ctx, "/externalauths/"+d.Id(), jsonData, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -327,6 +379,10 @@ func deleteExternalAuthLdap(
 	if err != nil {
 		return err
 	}
+	// already deleted outside of terraform
+	if code == http.StatusNotFound {
+		return nil
+	}
 	if code != http.StatusOK && code != http.StatusNoContent {
 		return fmt.Errorf("api doesn't return OK or NoContent: %d with body:\n%s", code, body)
 	}
@@ -336,12 +392,12 @@ func deleteExternalAuthLdap(
 
 func prepareExternalAuthLdapJSON(d *schema.ResourceData) jsonExternalAuthLdap {
 	return jsonExternalAuthLdap{
-		IsActiveDirectory:    d.Get("is_active_directory").(bool),
-		IsAnonymousAccess:    d.Get("is_anonymous_access").(bool),
-		IsProtectedUser:      d.Get("is_protected_user").(bool),
-		IsSSL:                d.Get("is_ssl").(bool),
-		IsStartTLS:           d.Get("is_starttls").(bool),
-		UsePrimaryAuthDomain: d.Get("use_primary_auth_domain").(bool),
+		IsActiveDirectory:    jsonBool(d.Get("is_active_directory").(bool)),
+		IsAnonymousAccess:    jsonBool(d.Get("is_anonymous_access").(bool)),
+		IsProtectedUser:      jsonBool(d.Get("is_protected_user").(bool)),
+		IsSSL:                jsonBool(d.Get("is_ssl").(bool)),
+		IsStartTLS:           jsonBool(d.Get("is_starttls").(bool)),
+		UsePrimaryAuthDomain: jsonBool(d.Get("use_primary_auth_domain").(bool)),
 		Timeout:              d.Get("timeout").(float64),
 		AuthenticationName:   d.Get("authentication_name").(string),
 		CACertificate:        d.Get("ca_certificate").(string),
@@ -354,7 +410,7 @@ func prepareExternalAuthLdapJSON(d *schema.ResourceData) jsonExternalAuthLdap {
 		Host:                 d.Get("host").(string),
 		Password:             d.Get("password").(string),
 		Passphrase:           d.Get("passphrase").(string),
-		Port:                 d.Get("port").(int),
+		Port:                 jsonInt(d.Get("port").(int)),
 		PrivateKey:           d.Get("private_key").(string),
 		Type:                 "LDAP",
 	}
@@ -378,7 +434,7 @@ func readExternalAuthLdapOptions(
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
 
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
@@ -405,7 +461,7 @@ func fillExternalAuthLdap(d *schema.ResourceData, jsonData jsonExternalAuthLdap)
 	if tfErr := d.Set("login_attribute", jsonData.LoginAttribute); tfErr != nil {
 		panic(tfErr)
 	}
-	if tfErr := d.Set("port", jsonData.Port); tfErr != nil {
+	if tfErr := d.Set("port", int(jsonData.Port)); tfErr != nil {
 		panic(tfErr)
 	}
 	if tfErr := d.Set("timeout", jsonData.Timeout); tfErr != nil {
@@ -417,22 +473,22 @@ func fillExternalAuthLdap(d *schema.ResourceData, jsonData jsonExternalAuthLdap)
 	if tfErr := d.Set("description", jsonData.Description); tfErr != nil {
 		panic(tfErr)
 	}
-	if tfErr := d.Set("is_active_directory", jsonData.IsActiveDirectory); tfErr != nil {
+	if tfErr := d.Set("is_active_directory", bool(jsonData.IsActiveDirectory)); tfErr != nil {
 		panic(tfErr)
 	}
-	if tfErr := d.Set("is_anonymous_access", jsonData.IsAnonymousAccess); tfErr != nil {
+	if tfErr := d.Set("is_anonymous_access", bool(jsonData.IsAnonymousAccess)); tfErr != nil {
 		panic(tfErr)
 	}
-	if tfErr := d.Set("is_protected_user", jsonData.IsProtectedUser); tfErr != nil {
+	if tfErr := d.Set("is_protected_user", bool(jsonData.IsProtectedUser)); tfErr != nil {
 		panic(tfErr)
 	}
-	if tfErr := d.Set("is_ssl", jsonData.IsSSL); tfErr != nil {
+	if tfErr := d.Set("is_ssl", bool(jsonData.IsSSL)); tfErr != nil {
 		panic(tfErr)
 	}
-	if tfErr := d.Set("is_starttls", jsonData.IsStartTLS); tfErr != nil {
+	if tfErr := d.Set("is_starttls", bool(jsonData.IsStartTLS)); tfErr != nil {
 		panic(tfErr)
 	}
-	if tfErr := d.Set("use_primary_auth_domain", jsonData.UsePrimaryAuthDomain); tfErr != nil {
+	if tfErr := d.Set("use_primary_auth_domain", bool(jsonData.UsePrimaryAuthDomain)); tfErr != nil {
 		panic(tfErr)
 	}
 }
@@ -1,11 +1,114 @@
 package bastion_test
 
 import (
+	"context"
+	"strings"
 	"testing"
 
+	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
+func TestResourceExternalAuthLDAP_attributeDefaults(t *testing.T) {
+	res := bastion.Provider().ResourcesMap["wallix-bastion_externalauth_ldap"]
+	for _, tc := range []struct {
+		isActiveDirectory bool
+		loginAttribute    interface{}
+		wantCN            string
+		wantLogin         string
+	}{
+		{true, nil, "sAMAccountName", "sAMAccountName"},
+		{false, nil, "cn", "uid"},
+		{false, "mail", "cn", "mail"},
+	} {
+		config := map[string]interface{}{
+			"authentication_name": "ldap",
+			"host":                "server1",
+			"ldap_base":           "DC=test",
+			"port":                389,
+			"timeout":             10,
+			"is_active_directory": tc.isActiveDirectory,
+		}
+		if tc.loginAttribute != nil {
+			config["login_attribute"] = tc.loginAttribute
+		}
+		diff, err := res.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+		if err != nil {
+			t.Fatalf("diff: %v", err)
+		}
+		if v := diff.Attributes["cn_attribute"].New; v != tc.wantCN {
+			t.Errorf("is_active_directory = %t: cn_attribute = %q, want %q", tc.isActiveDirectory, v, tc.wantCN)
+		}
+		if v := diff.Attributes["login_attribute"].New; v != tc.wantLogin {
+			t.Errorf("is_active_directory = %t: login_attribute = %q, want %q", tc.isActiveDirectory, v, tc.wantLogin)
+		}
+	}
+}
+
+// testExternalAuthLDAPConfig returns a minimal config of wallix-bastion_externalauth_ldap with extra arguments.
+func testExternalAuthLDAPConfig(extra map[string]interface{}) *terraform.ResourceConfig {
+	config := map[string]interface{}{
+		"authentication_name": "ldap",
+		"host":                "server1",
+		"ldap_base":           "DC=test",
+		"port":                389,
+		"timeout":             10,
+	}
+	for k, v := range extra {
+		config[k] = v
+	}
+
+	return terraform.NewResourceConfigRaw(config)
+}
+
+func TestResourceExternalAuthLDAP_anonymousAccess(t *testing.T) {
+	res := bastion.Provider().ResourcesMap["wallix-bastion_externalauth_ldap"]
+	for _, extra := range []map[string]interface{}{
+		{"is_anonymous_access": true},
+		{"is_anonymous_access": false, "login": "svc1", "password": "aPassword"},
+	} {
+		if _, err := res.Diff(context.Background(), nil, testExternalAuthLDAPConfig(extra), nil); err != nil {
+			t.Errorf("%v: unexpected error: %v", extra, err)
+		}
+	}
+	_, err := res.Diff(context.Background(), nil, testExternalAuthLDAPConfig(map[string]interface{}{
+		"is_anonymous_access": true,
+		"login":               "svc1",
+		"password":            "aPassword",
+	}), nil)
+	if err == nil {
+		t.Fatal("anonymous access with a login and a password: expected an error")
+	}
+	if !strings.Contains(err.Error(), "login, password can't be set with is_anonymous_access = true") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestResourceExternalAuthLDAP_sslStartTLS(t *testing.T) {
+	res := bastion.Provider().ResourcesMap["wallix-bastion_externalauth_ldap"]
+	for _, extra := range []map[string]interface{}{
+		{"is_ssl": true},
+		{"is_starttls": true},
+		{"is_ssl": false, "is_starttls": false},
+	} {
+		if _, err := res.Diff(context.Background(), nil, testExternalAuthLDAPConfig(extra), nil); err != nil {
+			t.Errorf("%v: unexpected error: %v", extra, err)
+		}
+	}
+	_, err := res.Diff(context.Background(), nil, testExternalAuthLDAPConfig(map[string]interface{}{
+		"is_ssl":      true,
+		"is_starttls": true,
+	}), nil)
+	if err == nil {
+		t.Fatal("is_ssl and is_starttls both true: expected an error")
+	}
+	if !strings.Contains(err.Error(), "is_ssl (LDAPS) and is_starttls (STARTTLS) can't be both true") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
 func TestAccResourceExternalAuthLDAP_basic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
@@ -2,7 +2,6 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"slices"
@@ -13,15 +12,15 @@ import (
 )
 
 type jsonExternalAuthRadius struct {
-	Port                 int     `json:"port"`
-	Timeout              float64 `json:"timeout"`
-	ID                   string  `json:"id,omitempty"`
-	AuthenticationName   string  `json:"authentication_name"`
-	Description          string  `json:"description"`
-	Host                 string  `json:"host"`
-	Secret               string  `json:"secret"`
-	Type                 string  `json:"type"`
-	UsePrimaryAuthDomain bool    `json:"use_primary_auth_domain"`
+	Port                 jsonInt  `json:"port"`
+	Timeout              float64  `json:"timeout"`
+	ID                   string   `json:"id,omitempty"`
+	AuthenticationName   string   `json:"authentication_name"`
+	Description          string   `json:"description"`
+	Host                 string   `json:"host"`
+	Secret               string   `json:"secret"`
+	Type                 string   `json:"type"`
+	UsePrimaryAuthDomain jsonBool `json:"use_primary_auth_domain"`
 }
 
 func resourceExternalAuthRadius() *schema.Resource {
@@ -37,10 +36,13 @@ func resourceExternalAuthRadius() *schema.Resource {
 			"authentication_name": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"host": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateHost,
+				DiffSuppressFunc: suppressHostCaseDiff,
 			},
 			"port": {
 				Type:         schema.TypeInt,
@@ -73,7 +75,7 @@ func resourceExternalAuthRadiusVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_externalauth_radius not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_externalauth_radius", version)
 }
 
 func resourceExternalAuthRadiusCreate(
@@ -191,20 +193,20 @@ func searchResourceExternalAuthRadius(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/externalauths/?q=authentication_name="+authenticationName, http.MethodGet, nil)
+	var results []jsonExternalAuthRadius
+	body, code, err := c.newRequestJSON(ctx,
+		"/externalauths/?q=authentication_name="+authenticationName, http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonExternalAuthRadius
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
-	if len(results) == 1 {
-		return results[0].ID, true, nil
+	// the search can also return other external authentications with a related name or of another type
+	for _, v := range results {
+		if v.AuthenticationName == authenticationName && v.Type == "RADIUS" {
+			return v.ID, true, nil
+		}
 	}
 
 	return "", false, nil
@@ -231,7 +233,8 @@ func updateExternalAuthRadius(
 ) error {
 	c := m.(*Client)
 	jsonData := prepareExternalAuthRadiusJSON(d)
-	body, code, err := c.newRequest(ctx, "/externalauths/"+d.Id(), http.MethodPut, jsonData)
+	priorJSONData := prepareExternalAuthRadiusJSON(priorResourceData(resourceExternalAuthRadius(), d))
+	body, code, err := c.putIfChanged(ctx, "/externalauths/"+d.Id(), jsonData, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -261,11 +264,11 @@ func prepareExternalAuthRadiusJSON(d *schema.ResourceData) jsonExternalAuthRadiu
 	return jsonExternalAuthRadius{
 		AuthenticationName:   d.Get("authentication_name").(string),
 		Host:                 d.Get("host").(string),
-		Port:                 d.Get("port").(int),
+		Port:                 jsonInt(d.Get("port").(int)),
 		Secret:               d.Get("secret").(string),
 		Timeout:              d.Get("timeout").(float64),
 		Description:          d.Get("description").(string),
-		UsePrimaryAuthDomain: d.Get("use_primary_auth_domain").(bool),
+		UsePrimaryAuthDomain: jsonBool(d.Get("use_primary_auth_domain").(bool)),
 		Type:                 "RADIUS",
 	}
 }
@@ -288,7 +291,7 @@ func readExternalAuthRadiusOptions(
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
 
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
@@ -303,7 +306,7 @@ func fillExternalAuthRadius(d *schema.ResourceData, jsonData jsonExternalAuthRad
 	if tfErr := d.Set("host", jsonData.Host); tfErr != nil {
 		panic(tfErr)
 	}
-	if tfErr := d.Set("port", jsonData.Port); tfErr != nil {
+	if tfErr := d.Set("port", int(jsonData.Port)); tfErr != nil {
 		panic(tfErr)
 	}
 	if tfErr := d.Set("timeout", jsonData.Timeout); tfErr != nil {
@@ -312,7 +315,7 @@ func fillExternalAuthRadius(d *schema.ResourceData, jsonData jsonExternalAuthRad
 	if tfErr := d.Set("description", jsonData.Description); tfErr != nil {
 		panic(tfErr)
 	}
-	if tfErr := d.Set("use_primary_auth_domain", jsonData.UsePrimaryAuthDomain); tfErr != nil {
+	if tfErr := d.Set("use_primary_auth_domain", bool(jsonData.UsePrimaryAuthDomain)); tfErr != nil {
 		panic(tfErr)
 	}
 }
@@ -1,11 +1,53 @@
 package bastion_test
 
 import (
+	"net/http"
 	"testing"
 
+	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
+// TestResourceExternalAuthRadius_importType checks that only a RADIUS external authentication
+// with the exact name is imported.
+func TestResourceExternalAuthRadius_importType(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/externalauths/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/" + bastion.VersionWallixAPI312 + "/externalauths/":
+			_, _ = w.Write([]byte(`[{"id":"1","authentication_name":"mfa","type":"LDAP"},` +
+				`{"id":"2","authentication_name":"mfa2","type":"RADIUS"},` +
+				`{"id":"3","authentication_name":"mfa","type":"RADIUS"}]`))
+		case "/api/" + bastion.VersionWallixAPI312 + "/externalauths/3":
+			_, _ = w.Write([]byte(`{"id":"3","authentication_name":"mfa","type":"RADIUS",` +
+				`"host":"radius.local","port":1812,"timeout":5}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	provider := testProviderWithServer(t, mux)
+	res := provider.ResourcesMap["wallix-bastion_externalauth_radius"]
+	d := res.Data(nil)
+	d.SetId("mfa")
+	result, err := res.Importer.State(d, provider.Meta())
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if len(result) != 1 || result[0].Id() != "3" {
+		t.Fatalf("imported id = %q, want %q", result[0].Id(), "3")
+	}
+	if v := result[0].Get("host").(string); v != "radius.local" {
+		t.Errorf("host = %q, want %q", v, "radius.local")
+	}
+
+	d = res.Data(nil)
+	d.SetId("other")
+	if _, err := res.Importer.State(d, provider.Meta()); err == nil {
+		t.Error("import of a missing external authentication: expected an error")
+	}
+}
+
 func TestAccResourceExternalAuthRadius_basic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
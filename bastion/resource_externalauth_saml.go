@@ -2,7 +2,6 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"slices"
@@ -29,6 +28,7 @@ type jsonExternalAuthSaml struct {
 	SPMetadata                 string                                  `json:"sp_metadata,omitempty"`
 	SPSingleLogoutService      string                                  `json:"sp_single_logout_service,omitempty"`
 	Type                       string                                  `json:"type"`
+	UsePrimaryAuthDomain       jsonBool                                `json:"use_primary_auth_domain,omitempty"`
 	ClaimCustomization         *jsonExternalAuthSamlClaimCustomization `json:"claim_customization,omitempty"`
 }
 
@@ -112,6 +112,10 @@ func resourceExternalAuthSaml() *schema.Resource {
 				Optional:  true,
 				Sensitive: true,
 			},
+			"use_primary_auth_domain": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 			"idp_entity_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -149,7 +153,7 @@ func resourceExternalAuthSamlVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_externalauth_saml not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_externalauth_saml", version)
 }
 
 func resourceExternalAuthSamlCreate(
@@ -267,20 +271,20 @@ func searchResourceExternalAuthSaml(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/externalauths/?q=authentication_name="+authenticationName, http.MethodGet, nil)
+	var results []jsonExternalAuthSaml
+	body, code, err := c.newRequestJSON(ctx,
+		"/externalauths/?q=authentication_name="+authenticationName, http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonExternalAuthSaml
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
-	if len(results) == 1 {
-		return results[0].ID, true, nil
+	// the search can also return other external authentications with a related name or of another type
+	for _, v := range results {
+		if v.AuthenticationName == authenticationName && v.Type == "SAML" {
+			return v.ID, true, nil
+		}
 	}
 
 	return "", false, nil
@@ -307,7 +311,8 @@ func updateExternalAuthSaml(
 ) error {
 	c := m.(*Client)
 	jsonData := prepareExternalAuthSamlJSON(d)
-	body, code, err := c.newRequest(ctx, "/externalauths/"+d.Id(), http.MethodPut, jsonData)
+	priorJSONData := prepareExternalAuthSamlJSON(priorResourceData(resourceExternalAuthSaml(), d))
+	body, code, err := c.putIfChanged(ctx, "/externalauths/"+d.Id(), jsonData, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -335,14 +340,15 @@ func deleteExternalAuthSaml(
 
 func prepareExternalAuthSamlJSON(d *schema.ResourceData) jsonExternalAuthSaml {
 	jsonData := jsonExternalAuthSaml{
-		AuthenticationName: d.Get("authentication_name").(string),
-		Type:               "SAML",
-		IDPMetadata:        d.Get("idp_metadata").(string),
-		Timeout:            d.Get("timeout").(float64),
-		Certificate:        d.Get("certificate").(string),
-		Description:        d.Get("description").(string),
-		Passphrase:         d.Get("passphrase").(string),
-		PrivateKey:         d.Get("private_key").(string),
+		AuthenticationName:   d.Get("authentication_name").(string),
+		Type:                 "SAML",
+		IDPMetadata:          d.Get("idp_metadata").(string),
+		Timeout:              d.Get("timeout").(float64),
+		Certificate:          d.Get("certificate").(string),
+		Description:          d.Get("description").(string),
+		Passphrase:           d.Get("passphrase").(string),
+		PrivateKey:           d.Get("private_key").(string),
+		UsePrimaryAuthDomain: jsonBool(d.Get("use_primary_auth_domain").(bool)),
 	}
 	for _, v := range d.Get("claim_customization").([]interface{}) {
 		if v == nil {
@@ -379,7 +385,7 @@ func readExternalAuthSamlOptions(
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
 
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
@@ -400,6 +406,9 @@ func fillExternalAuthSaml(d *schema.ResourceData, jsonData jsonExternalAuthSaml)
 	if tfErr := d.Set("description", jsonData.Description); tfErr != nil {
 		panic(tfErr)
 	}
+	if tfErr := d.Set("use_primary_auth_domain", bool(jsonData.UsePrimaryAuthDomain)); tfErr != nil {
+		panic(tfErr)
+	}
 	if tfErr := d.Set("idp_entity_id", jsonData.IDPEntityID); tfErr != nil {
 		panic(tfErr)
 	}
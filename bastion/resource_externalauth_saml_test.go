@@ -1,15 +1,66 @@
 package bastion_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"testing"
 
 	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// TestResourceExternalAuthSaml_create checks that use_primary_auth_domain is sent and that an external
+// authentication of another type with the same name isn't seen as the SAML one.
+func TestResourceExternalAuthSaml_create(t *testing.T) {
+	auths := []map[string]interface{}{
+		{"id": "1", "authentication_name": "sso", "type": "LDAP"},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/externalauths/", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/"+bastion.VersionWallixAPI312+"/externalauths/" && r.Method == http.MethodPost:
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body["type"] != "SAML" || body["use_primary_auth_domain"] != true {
+				t.Errorf("unexpected body on create: %v", body)
+			}
+			body["id"] = "2"
+			auths = append(auths, body)
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/api/"+bastion.VersionWallixAPI312+"/externalauths/":
+			_ = json.NewEncoder(w).Encode(auths)
+		case r.URL.Path == "/api/"+bastion.VersionWallixAPI312+"/externalauths/2" && len(auths) == 2:
+			_ = json.NewEncoder(w).Encode(auths[1])
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	provider := testProviderWithServer(t, mux)
+	res := provider.ResourcesMap["wallix-bastion_externalauth_saml"]
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"authentication_name":     "sso",
+		"idp_metadata":            "<xml/>",
+		"timeout":                 10,
+		"use_primary_auth_domain": true,
+	})
+	if diags := res.CreateContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "2" {
+		t.Errorf("id = %q, want %q", d.Id(), "2")
+	}
+	if !d.Get("use_primary_auth_domain").(bool) {
+		t.Error("use_primary_auth_domain = false after create, want true")
+	}
+}
+
 func TestAccResourceExternalAuthSaml_basic38(t *testing.T) {
 	if v := os.Getenv("WALLIX_BASTION_API_VERSION"); v == bastion.VersionWallixAPI38 {
 		resource.Test(t, resource.TestCase{
@@ -2,7 +2,6 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"slices"
@@ -13,14 +12,15 @@ import (
 )
 
 type jsonExternalAuthTacacs struct {
-	Port                 int    `json:"port"`
-	ID                   string `json:"id,omitempty"`
-	AuthenticationName   string `json:"authentication_name"`
-	Description          string `json:"description"`
-	Host                 string `json:"host"`
-	Secret               string `json:"secret"`
-	Type                 string `json:"type"`
-	UsePrimaryAuthDomain bool   `json:"use_primary_auth_domain"`
+	Port                 jsonInt  `json:"port"`
+	Timeout              float64  `json:"timeout,omitempty"`
+	ID                   string   `json:"id,omitempty"`
+	AuthenticationName   string   `json:"authentication_name"`
+	Description          string   `json:"description"`
+	Host                 string   `json:"host"`
+	Secret               string   `json:"secret"`
+	Type                 string   `json:"type"`
+	UsePrimaryAuthDomain jsonBool `json:"use_primary_auth_domain"`
 }
 
 func resourceExternalAuthTacacs() *schema.Resource {
@@ -38,8 +38,10 @@ func resourceExternalAuthTacacs() *schema.Resource {
 				Required: true,
 			},
 			"host": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateHost,
+				DiffSuppressFunc: suppressHostCaseDiff,
 			},
 			"port": {
 				Type:         schema.TypeInt,
@@ -51,6 +53,12 @@ func resourceExternalAuthTacacs() *schema.Resource {
 				Required:  true,
 				Sensitive: true,
 			},
+			"timeout": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
 			"description": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -68,7 +76,7 @@ func resourceExternalAuthTacacsVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_externalauth_tacacs not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_externalauth_tacacs", version)
 }
 
 func resourceExternalAuthTacacsCreate(
@@ -186,20 +194,20 @@ func searchResourceExternalAuthTacacs(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/externalauths/?q=authentication_name="+authenticationName, http.MethodGet, nil)
+	var results []jsonExternalAuthTacacs
+	body, code, err := c.newRequestJSON(ctx,
+		"/externalauths/?q=authentication_name="+authenticationName, http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonExternalAuthTacacs
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
-	if len(results) == 1 {
-		return results[0].ID, true, nil
+	// the search can also return other external authentications with a related name or of another type
+	for _, v := range results {
+		if v.AuthenticationName == authenticationName && v.Type == "TACACS+" {
+			return v.ID, true, nil
+		}
 	}
 
 	return "", false, nil
@@ -226,7 +234,8 @@ func updateExternalAuthTacacs(
 ) error {
 	c := m.(*Client)
 	jsonData := prepareExternalAuthTacacsJSON(d)
-	body, code, err := c.newRequest(ctx, "/externalauths/"+d.Id(), http.MethodPut, jsonData)
+	priorJSONData := prepareExternalAuthTacacsJSON(priorResourceData(resourceExternalAuthTacacs(), d))
+	body, code, err := c.putIfChanged(ctx, "/externalauths/"+d.Id(), jsonData, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -256,10 +265,11 @@ func prepareExternalAuthTacacsJSON(d *schema.ResourceData) jsonExternalAuthTacac
 	return jsonExternalAuthTacacs{
 		AuthenticationName:   d.Get("authentication_name").(string),
 		Host:                 d.Get("host").(string),
-		Port:                 d.Get("port").(int),
+		Port:                 jsonInt(d.Get("port").(int)),
 		Secret:               d.Get("secret").(string),
+		Timeout:              d.Get("timeout").(float64),
 		Description:          d.Get("description").(string),
-		UsePrimaryAuthDomain: d.Get("use_primary_auth_domain").(bool),
+		UsePrimaryAuthDomain: jsonBool(d.Get("use_primary_auth_domain").(bool)),
 		Type:                 "TACACS+",
 	}
 }
@@ -282,7 +292,7 @@ func readExternalAuthTacacsOptions(
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
 
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
@@ -297,13 +307,16 @@ func fillExternalAuthTacacs(d *schema.ResourceData, jsonData jsonExternalAuthTac
 	if tfErr := d.Set("host", jsonData.Host); tfErr != nil {
 		panic(tfErr)
 	}
-	if tfErr := d.Set("port", jsonData.Port); tfErr != nil {
+	if tfErr := d.Set("port", int(jsonData.Port)); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("timeout", jsonData.Timeout); tfErr != nil {
 		panic(tfErr)
 	}
 	if tfErr := d.Set("description", jsonData.Description); tfErr != nil {
 		panic(tfErr)
 	}
-	if tfErr := d.Set("use_primary_auth_domain", jsonData.UsePrimaryAuthDomain); tfErr != nil {
+	if tfErr := d.Set("use_primary_auth_domain", bool(jsonData.UsePrimaryAuthDomain)); tfErr != nil {
 		panic(tfErr)
 	}
 }
@@ -1,11 +1,65 @@
 package bastion_test
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
 	"testing"
 
+	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// TestResourceExternalAuthTacacs_create checks that an external authentication of another type
+// with the same name isn't seen as the TACACS+ one.
+func TestResourceExternalAuthTacacs_create(t *testing.T) {
+	auths := []map[string]interface{}{
+		{"id": "1", "authentication_name": "netdev", "type": "LDAP"},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/externalauths/", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/"+bastion.VersionWallixAPI312+"/externalauths/" && r.Method == http.MethodPost:
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body["type"] != "TACACS+" || body["timeout"] != 3.5 {
+				t.Errorf("unexpected body on create: %v", body)
+			}
+			body["id"] = "2"
+			auths = append(auths, body)
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/api/"+bastion.VersionWallixAPI312+"/externalauths/":
+			_ = json.NewEncoder(w).Encode(auths)
+		case r.URL.Path == "/api/"+bastion.VersionWallixAPI312+"/externalauths/2" && len(auths) == 2:
+			_ = json.NewEncoder(w).Encode(auths[1])
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	provider := testProviderWithServer(t, mux)
+	res := provider.ResourcesMap["wallix-bastion_externalauth_tacacs"]
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"authentication_name": "netdev",
+		"host":                "tacacs.local",
+		"port":                49,
+		"secret":              "aSecret",
+		"timeout":             3.5,
+	})
+	if diags := res.CreateContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "2" {
+		t.Errorf("id = %q, want %q", d.Id(), "2")
+	}
+	if v := d.Get("timeout").(float64); v != 3.5 {
+		t.Errorf("timeout = %v, want 3.5", v)
+	}
+}
+
 func TestAccResourceExternalAuthTacacs_basic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
@@ -2,7 +2,6 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"slices"
@@ -275,7 +274,7 @@ func resourceProfileVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_profile not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_profile", version)
 }
 
 func resourceProfileCreate(
@@ -396,18 +395,14 @@ func searchResourceProfile(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/profiles/?q=profile_name="+profileName, http.MethodGet, nil)
+	var results []jsonProfile
+	body, code, err := c.newRequestJSON(ctx, "/profiles/?q=profile_name="+profileName, http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonProfile
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
 	if len(results) == 1 {
 		return results[0].ID, true, nil
 	}
@@ -436,7 +431,8 @@ func updateProfile(
 ) error {
 	c := m.(*Client)
 	jsonData := prepareProfileJSON(d, false)
-	body, code, err := c.newRequest(ctx, "/profiles/"+d.Id()+"?force=true", http.MethodPut, jsonData)
+	priorJSONData := prepareProfileJSON(priorResourceData(resourceProfile(), d), false)
+	body, code, err := c.putIfChanged(ctx, "/profiles/"+d.Id()+"?force=true", jsonData, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -620,7 +616,7 @@ func readProfileOptions(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
@@ -2,10 +2,10 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"slices"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -266,6 +266,10 @@ func resourceTargetGroup() *schema.Resource {
 					},
 				},
 			},
+			"href": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -275,7 +279,7 @@ func resourceTargetGroupVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_targetgroup not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_targetgroup", version)
 }
 
 func resourceTargetGroupCreate(
@@ -323,6 +327,9 @@ func resourceTargetGroupRead(
 		d.SetId("")
 	} else {
 		fillTargetGroup(d, cfg)
+		if tfErr := d.Set("href", c.apiURL("/targetgroups/"+url.PathEscape(d.Id()))); tfErr != nil {
+			panic(tfErr)
+		}
 	}
 
 	return nil
@@ -393,18 +400,14 @@ func searchResourceTargetGroup(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/targetgroups/?q=group_name="+groupName, http.MethodGet, nil)
+	var results []jsonTargetGroup
+	body, code, err := c.newRequestJSON(ctx, "/targetgroups/?q=group_name="+groupName, http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonTargetGroup
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
 	if len(results) == 1 {
 		return results[0].ID, true, nil
 	}
@@ -439,7 +442,11 @@ func updateTargetGroup(
 	if err != nil {
 		return err
 	}
-	body, code, err := c.newRequest(ctx, "/targetgroups/"+d.Id()+"?force=true", http.MethodPut, json)
+	var priorJSONData interface{}
+	if v, err := prepareTargetGroupJSON(priorResourceData(resourceTargetGroup(), d)); err == nil {
+		priorJSONData = v
+	}
+	body, code, err := c.putIfChanged(ctx, "/targetgroups/"+d.Id()+"?force=true", json, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -647,7 +654,7 @@ func readTargetGroupOptions(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
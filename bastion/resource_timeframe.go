@@ -2,7 +2,6 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"regexp"
@@ -96,7 +95,7 @@ func resourceTimeframeVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_timeframe not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_timeframe", version)
 }
 
 func resourceTimeframeCreate(
@@ -255,7 +254,11 @@ func updateTimeframe(
 	if err != nil {
 		return err
 	}
-	body, code, err := c.newRequest(ctx, "/timeframes/"+d.Id()+"?force=true", http.MethodPut, jsonData)
+	var priorJSONData interface{}
+	if v, err := prepareTimeframeJSON(priorResourceData(resourceTimeframe(), d)); err == nil {
+		priorJSONData = v
+	}
+	body, code, err := c.putIfChanged(ctx, "/timeframes/"+d.Id()+"?force=true", jsonData, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -337,7 +340,7 @@ func readTimeframeOptions(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
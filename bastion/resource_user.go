@@ -2,14 +2,17 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
+	"encoding/base64"
 	"fmt"
 	"net/http"
+	"net/url"
 	"slices"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/crypto/ssh"
 )
 
 type jsonUser struct {
@@ -99,19 +102,56 @@ func resourceUser() *schema.Resource {
 				Computed:     true,
 			},
 			"ssh_public_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateSSHPublicKey,
+			},
+			"href": {
 				Type:     schema.TypeString,
-				Optional: true,
+				Computed: true,
 			},
 		},
 	}
 }
 
+// validateSSHPublicKey checks that each line of the value is an OpenSSH public key
+// in the authorized_keys format: [options] <type> <base64 key> [comment],
+// with a key of the type before it.
+func validateSSHPublicKey(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	var errs []error
+	for _, line := range strings.Split(v, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(line))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %q is not an OpenSSH public key: %w", k, line, err))
+
+			continue
+		}
+		// the type before the key isn't checked by the parser
+		fields := strings.Fields(line)
+		blob := base64.StdEncoding.EncodeToString(key.Marshal())
+		if i := slices.Index(fields, blob); i > 0 && fields[i-1] != key.Type() {
+			errs = append(errs, fmt.Errorf("%s: key of type %s doesn't contain a %s key but a %s key",
+				k, fields[i-1], fields[i-1], key.Type()))
+		}
+	}
+
+	return nil, errs
+}
+
 func resourceUserVersionCheck(version string) error {
 	if slices.Contains(defaultVersionsValid(), version) {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_user not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_user", version)
 }
 
 func resourceUserCreate(
@@ -152,6 +192,9 @@ func resourceUserRead(
 		d.SetId("")
 	} else {
 		fillUser(d, cfg)
+		if tfErr := d.Set("href", c.apiURL("/users/"+url.PathEscape(d.Get("user_name").(string)))); tfErr != nil {
+			panic(tfErr)
+		}
 	}
 
 	return nil
@@ -256,7 +299,8 @@ func updateUser(
 ) error {
 	c := m.(*Client)
 	jsonData := prepareUserJSON(d, false)
-	body, code, err := c.newRequest(ctx, "/users/"+d.Get("user_name").(string)+"?force=true", http.MethodPut, jsonData)
+	priorJSONData := prepareUserJSON(priorResourceData(resourceUser(), d), false)
+	body, code, err := c.putIfChanged(ctx, "/users/"+d.Get("user_name").(string)+"?force=true", jsonData, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -344,7 +388,7 @@ func readUserOptions(
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
 
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
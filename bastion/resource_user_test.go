@@ -1,9 +1,15 @@
 package bastion_test
 
 import (
+	"context"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func TestAccResourceUser_basic(t *testing.T) {
@@ -98,3 +104,54 @@ resource "wallix-bastion_user" "testacc_User" {
 }
 `
 }
+
+// TestResourceUser_hrefEscaped checks the user name is escaped in the href of the user.
+func TestResourceUser_sshPublicKey(t *testing.T) {
+	validate := bastion.Provider().ResourcesMap["wallix-bastion_user"].Schema["ssh_public_key"].ValidateFunc
+	key := "AAAAC3NzaC1lZDI1NTE5AAAAIFTxDtdjcNa1weV3Q07AlcYiBBOjlj+pqvmXoenh0pPK"
+	for _, tc := range []struct {
+		name  string
+		value string
+		err   string
+	}{
+		{"valid", "ssh-ed25519 " + key + " user@host", ""},
+		{"valid with options", `from="10.0.0.1,10.0.0.2",no-pty ssh-ed25519 ` + key + " user@host", ""},
+		{"several lines", "ssh-ed25519 " + key + "\nssh-ed25519 " + key + " other\n", ""},
+		{"bad base64", "ssh-ed25519 AAAA!!!not-base64 user@host", "is not an OpenSSH public key"},
+		{"type not matching the key", "ssh-rsa " + key + " user@host", "doesn't contain a ssh-rsa key"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, errs := validate(tc.value, "ssh_public_key")
+			switch {
+			case tc.err == "" && len(errs) > 0:
+				t.Errorf("unexpected errors: %v", errs)
+			case tc.err != "" && (len(errs) != 1 || !strings.Contains(errs[0].Error(), tc.err)):
+				t.Errorf("errors = %v, want one error with %q", errs, tc.err)
+			}
+		})
+	}
+}
+
+func TestResourceUser_hrefEscaped(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/users/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/"+bastion.VersionWallixAPI312+"/users/j doe" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+		_, _ = w.Write([]byte(`{"user_name":"j doe","email":"jdoe@none.none","profile":"user"}`))
+	})
+	provider := testProviderWithServer(t, mux)
+	res := provider.ResourcesMap["wallix-bastion_user"]
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"user_name": "j doe",
+	})
+	d.SetId("j doe")
+	if diags := res.ReadContext(context.Background(), d, provider.Meta()); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if v := d.Get("href").(string); !strings.HasSuffix(v, "/api/"+bastion.VersionWallixAPI312+"/users/j%20doe") {
+		t.Errorf("href = %q, want the user name escaped", v)
+	}
+}
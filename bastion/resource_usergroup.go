@@ -2,10 +2,11 @@ package bastion
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"slices"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -36,6 +37,11 @@ func resourceUserGroup() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"allow_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
 			"timeframes": {
 				Type:     schema.TypeSet,
 				Required: true,
@@ -89,6 +95,10 @@ func resourceUserGroup() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Computed: true,
 			},
+			"href": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -98,7 +108,7 @@ func resourceUserGroupVersionCheck(version string) error {
 		return nil
 	}
 
-	return fmt.Errorf("resource wallix-bastion_usergroup not available with api version %s", version)
+	return versionNotAvailableError("resource", "wallix-bastion_usergroup", version)
 }
 
 func resourceUserGroupCreate(
@@ -115,6 +125,9 @@ func resourceUserGroupCreate(
 	if ex {
 		return diag.FromErr(fmt.Errorf("group_name %s already exists", d.Get("group_name").(string)))
 	}
+	if err := checkUserGroupTimeframes(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
 	err = addUserGroup(ctx, d, m)
 	if err != nil {
 		return diag.FromErr(err)
@@ -146,6 +159,9 @@ func resourceUserGroupRead(
 		d.SetId("")
 	} else {
 		fillUserGroup(d, cfg)
+		if tfErr := d.Set("href", c.apiURL("/usergroups/"+url.PathEscape(d.Id()))); tfErr != nil {
+			panic(tfErr)
+		}
 	}
 
 	return nil
@@ -159,8 +175,15 @@ func resourceUserGroupUpdate(
 	if err := resourceUserGroupVersionCheck(c.bastionAPIVersion); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := updateUserGroup(ctx, d, m); err != nil {
-		return diag.FromErr(err)
+	if d.HasChangesExcept("allow_destroy") {
+		if d.HasChange("timeframes") {
+			if err := checkUserGroupTimeframes(ctx, d, m); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if err := updateUserGroup(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 	d.Partial(false)
 
@@ -174,6 +197,9 @@ func resourceUserGroupDelete(
 	if err := resourceUserGroupVersionCheck(c.bastionAPIVersion); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := checkAllowDestroy(d, "wallix-bastion_usergroup"); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := deleteUserGroup(ctx, d, m); err != nil {
 		return diag.FromErr(err)
 	}
@@ -203,6 +229,9 @@ func resourceUserGroupImport(
 		return nil, err
 	}
 	fillUserGroup(d, cfg)
+	if tfErr := d.Set("allow_destroy", true); tfErr != nil {
+		panic(tfErr)
+	}
 	result := make([]*schema.ResourceData, 1)
 	d.SetId(id)
 	result[0] = d
@@ -216,18 +245,14 @@ func searchResourceUserGroup(
 	string, bool, error,
 ) {
 	c := m.(*Client)
-	body, code, err := c.newRequest(ctx, "/usergroups/?q=group_name="+groupName, http.MethodGet, nil)
+	var results []jsonUserGroup
+	body, code, err := c.newRequestJSON(ctx, "/usergroups/?q=group_name="+groupName, http.MethodGet, nil, &results)
 	if err != nil {
 		return "", false, err
 	}
 	if code != http.StatusOK {
 		return "", false, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	var results []jsonUserGroup
-	err = json.Unmarshal([]byte(body), &results)
-	if err != nil {
-		return "", false, fmt.Errorf("unmarshaling json: %w", err)
-	}
 	if len(results) == 1 {
 		return results[0].ID, true, nil
 	}
@@ -235,6 +260,29 @@ func searchResourceUserGroup(
 	return "", false, nil
 }
 
+// checkUserGroupTimeframes returns an error with the timeframes of the group which don't exist,
+// to not depend on the error returned by the api.
+func checkUserGroupTimeframes(
+	ctx context.Context, d *schema.ResourceData, m interface{},
+) error {
+	var missing []string
+	for _, v := range d.Get("timeframes").(*schema.Set).List() {
+		cfg, err := readTimeframeOptions(ctx, v.(string), m)
+		if err != nil {
+			return err
+		}
+		if cfg.TimeframeName == "" {
+			missing = append(missing, v.(string))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("timeframes %s don't exist on group_name %s",
+			strings.Join(missing, ", "), d.Get("group_name").(string))
+	}
+
+	return nil
+}
+
 func addUserGroup(
 	ctx context.Context, d *schema.ResourceData, m interface{},
 ) error {
@@ -256,7 +304,8 @@ func updateUserGroup(
 ) error {
 	c := m.(*Client)
 	jsonData := prepareUserGroupJSON(d)
-	body, code, err := c.newRequest(ctx, "/usergroups/"+d.Id()+"?force=true", http.MethodPut, jsonData)
+	priorJSONData := prepareUserGroupJSON(priorResourceData(resourceUserGroup(), d))
+	body, code, err := c.putIfChanged(ctx, "/usergroups/"+d.Id()+"?force=true", jsonData, priorJSONData)
 	if err != nil {
 		return err
 	}
@@ -335,7 +384,7 @@ func readUserGroupOptions(
 	if code != http.StatusOK {
 		return result, fmt.Errorf("api doesn't return OK: %d with body:\n%s", code, body)
 	}
-	err = json.Unmarshal([]byte(body), &result)
+	err = c.unmarshalJSON(ctx, body, &result)
 	if err != nil {
 		return result, fmt.Errorf("unmarshaling json: %w", err)
 	}
@@ -1,9 +1,15 @@
 package bastion_test
 
 import (
+	"context"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/wallix/terraform-provider-wallix-bastion/bastion"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func TestAccResourceUserGroup_basic(t *testing.T) {
@@ -37,6 +43,40 @@ func TestAccResourceUserGroup_basic(t *testing.T) {
 	})
 }
 
+// TestResourceUserGroup_missingTimeframes checks the group isn't created with timeframes which don't exist.
+func TestResourceUserGroup_missingTimeframes(t *testing.T) {
+	posted := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/usergroups/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			posted = true
+		}
+		_, _ = w.Write([]byte(`[]`))
+	})
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/timeframes/allthetime", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"timeframe_name":"allthetime"}`))
+	})
+	mux.HandleFunc("/api/"+bastion.VersionWallixAPI312+"/timeframes/", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	provider := testProviderWithServer(t, mux)
+	res := provider.ResourcesMap["wallix-bastion_usergroup"]
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"group_name": "contractors",
+		"timeframes": []interface{}{"allthetime", "office_hours"},
+	})
+	diags := res.CreateContext(context.Background(), d, provider.Meta())
+	if !diags.HasError() {
+		t.Fatal("create with a missing timeframe doesn't return an error")
+	}
+	if !strings.Contains(diags[0].Summary, "timeframes office_hours don't exist") {
+		t.Errorf("unexpected error: %s", diags[0].Summary)
+	}
+	if posted {
+		t.Error("group created with a missing timeframe")
+	}
+}
+
 func testAccResourceUserGroupCreate() string {
 	return `
 resource "random_password" "testacc_Usergroup" {